@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configOutput string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and change settings",
+	Long: `View and change the settings stored in app_config.json.
+
+Every value is type checked and validated before it is saved.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		rootCmd.PersistentPreRun(cmd, args)
+		cmd.SilenceUsage = true
+		if configOutput != "text" && configOutput != "json" {
+			return fmt.Errorf("invalid output format %q (use text or json)", configOutput)
+		}
+		// Custom languages must be known before language values are validated
+		return cfgManager.LoadLanguages()
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := cfgManager.Load()
+		if err != nil {
+			return err
+		}
+
+		if configOutput == "json" {
+			return printJSON(cfg)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tVALUE\tTYPE\tDESCRIPTION")
+		for _, field := range config.Fields {
+			fmt.Fprintf(w, "%s\t%v\t%s\t%s\n", field.Key, field.Get(cfg), field.Type, field.Description)
+		}
+		return w.Flush()
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		field, err := config.LookupField(args[0])
+		if err != nil {
+			return err
+		}
+
+		cfg, err := cfgManager.Load()
+		if err != nil {
+			return err
+		}
+
+		if configOutput == "json" {
+			return printJSON(map[string]interface{}{field.Key: field.Get(cfg)})
+		}
+		fmt.Println(field.Get(cfg))
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting",
+	Example: `  polyglot config set level B1
+  polyglot config set auto_translate false
+  polyglot config set daily_goal 3`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		field, err := config.LookupField(args[0])
+		if err != nil {
+			return err
+		}
+
+		cfg, err := cfgManager.Load()
+		if err != nil {
+			return err
+		}
+
+		if err := field.Set(cfg, args[1]); err != nil {
+			return err
+		}
+		if err := cfgManager.Save(cfg); err != nil {
+			return err
+		}
+
+		if configOutput == "json" {
+			return printJSON(map[string]interface{}{field.Key: field.Get(cfg)})
+		}
+		fmt.Printf("✅ %s set to %v\n", field.Key, field.Get(cfg))
+		return nil
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the config file in $EDITOR",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := cfgManager.Load()
+		if err != nil {
+			return err
+		}
+
+		// Edit a copy so a broken file never replaces the working config
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+		tmpDir, err := os.MkdirTemp("", "polyglot-config")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+
+		tmpFile := filepath.Join(tmpDir, filepath.Base(cfgManager.ConfigFile()))
+		if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+			return err
+		}
+
+		// Editors may come with arguments, like "code -w"
+		editorArgs := strings.Fields(os.Getenv("VISUAL"))
+		if len(editorArgs) == 0 {
+			editorArgs = strings.Fields(os.Getenv("EDITOR"))
+		}
+		if len(editorArgs) == 0 {
+			editorArgs = []string{"vi"}
+		}
+		editorCmd := exec.Command(editorArgs[0], append(editorArgs[1:], tmpFile)...)
+		editorCmd.Stdin, editorCmd.Stdout, editorCmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := editorCmd.Run(); err != nil {
+			return fmt.Errorf("editor failed: %w", err)
+		}
+
+		edited, err := os.ReadFile(tmpFile)
+		if err != nil {
+			return err
+		}
+
+		// Settings removed while editing fall back to their defaults
+		newCfg := config.DefaultConfig()
+		if err := json.Unmarshal(edited, newCfg); err != nil {
+			return fmt.Errorf("config not saved, invalid JSON: %w", err)
+		}
+		if err := newCfg.Validate(); err != nil {
+			return fmt.Errorf("config not saved: %w", err)
+		}
+		if err := cfgManager.Save(newCfg); err != nil {
+			return err
+		}
+
+		fmt.Println("✅ Config saved")
+		return nil
+	},
+}
+
+var configResetCmd = &cobra.Command{
+	Use:   "reset [key]",
+	Short: "Reset one or all settings to their defaults",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defaults := config.DefaultConfig()
+
+		if len(args) == 0 {
+			if err := cfgManager.Save(defaults); err != nil {
+				return err
+			}
+			if configOutput == "json" {
+				return printJSON(defaults)
+			}
+			fmt.Println("✅ All settings reset to defaults")
+			return nil
+		}
+
+		field, err := config.LookupField(args[0])
+		if err != nil {
+			return err
+		}
+
+		cfg, err := cfgManager.Load()
+		if err != nil {
+			return err
+		}
+		if err := field.Set(cfg, fmt.Sprint(field.Get(defaults))); err != nil {
+			return err
+		}
+		if err := cfgManager.Save(cfg); err != nil {
+			return err
+		}
+
+		if configOutput == "json" {
+			return printJSON(map[string]interface{}{field.Key: field.Get(cfg)})
+		}
+		fmt.Printf("✅ %s reset to %v\n", field.Key, field.Get(cfg))
+		return nil
+	},
+}
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func init() {
+	configCmd.PersistentFlags().StringVarP(&configOutput, "output", "o", "text", "output format: text or json")
+	configCmd.AddCommand(configListCmd, configGetCmd, configSetCmd, configEditCmd, configResetCmd)
+	rootCmd.AddCommand(configCmd)
+}
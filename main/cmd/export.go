@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/anki"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportFile   string
+	exportFilter db.VocabFilter
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export learning data to other tools",
+}
+
+var exportAnkiCmd = &cobra.Command{
+	Use:   "anki",
+	Short: "Export vocabulary as an Anki deck",
+	Long: `Export collected vocabulary as an Anki deck with one note per word.
+
+Each note has the word, its translation, the example sentence and the
+sentence of the source story that uses it, tagged with language, level
+and topic. Note ids are derived from the word, so importing a newer
+export updates existing cards instead of duplicating them.
+
+Formats:
+  apkg  Anki package, import with File > Import
+  tsv   Anki text import format (Anki 2.1.55 or newer), using the note
+        type created by importing an apkg export once`,
+	Example: `  polyglot export anki
+  polyglot export anki --language russian --format tsv --file russian.txt`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		if err := cfgManager.LoadLanguages(); err != nil {
+			return err
+		}
+		if exportFormat != "apkg" && exportFormat != "tsv" {
+			return fmt.Errorf("invalid format %q (use apkg or tsv)", exportFormat)
+		}
+
+		store, _, err := db.OpenLocal(cfgManager.DatabaseFile())
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		exportFilter.Level = strings.ToUpper(exportFilter.Level)
+		entries, err := store.ListVocabulary(exportFilter)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("no vocabulary to export")
+		}
+
+		storyTexts := map[int64]string{}
+		notes := anki.BuildNotes(entries, func(id int64) string {
+			if storyText, ok := storyTexts[id]; ok {
+				return storyText
+			}
+			storyText, _ := store.StoryText(id)
+			storyTexts[id] = storyText
+			return storyText
+		})
+
+		file := exportFile
+		if file == "" {
+			name := "all"
+			if exportFilter.Language != "" {
+				name = exportFilter.Language
+			}
+			file = fmt.Sprintf("polyglot-%s.%s", name, map[string]string{"apkg": "apkg", "tsv": "txt"}[exportFormat])
+		}
+
+		if exportFormat == "apkg" {
+			err = anki.WriteAPKG(file, notes)
+		} else {
+			err = writeTSVFile(file, notes)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Exported %d notes to %s\n", len(notes), file)
+		return nil
+	},
+}
+
+func writeTSVFile(path string, notes []anki.Note) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err := anki.WriteTSV(out, notes); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func init() {
+	exportAnkiCmd.Flags().StringVarP(&exportFormat, "format", "f", "apkg", "apkg or tsv")
+	exportAnkiCmd.Flags().StringVar(&exportFile, "file", "", "output file (default polyglot-<language>.apkg or .txt)")
+	exportAnkiCmd.Flags().StringVarP(&exportFilter.Language, "language", "l", "", "only this language")
+	exportAnkiCmd.Flags().StringVar(&exportFilter.Level, "level", "", "only this CEFR level")
+	exportAnkiCmd.Flags().StringVarP(&exportFilter.Tag, "tag", "t", "", "only words with this tag")
+	exportAnkiCmd.Flags().BoolVarP(&exportFilter.IncludeKnown, "all", "a", false, "include words marked as known")
+
+	exportCmd.AddCommand(exportAnkiCmd)
+	rootCmd.AddCommand(exportCmd)
+}
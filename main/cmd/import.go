@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import learning data from other sources",
+}
+
+var importLegacyDBCmd = &cobra.Command{
+	Use:   "legacy-db <path>",
+	Short: "Import the SQLite database of the bash version",
+	Long: `Import stories, vocabulary, exercise results, sessions and study time
+from the database written by polygot-test.sh (its DB_FILE, usually
+~/.local/share/polyglot-stories/stories.db).
+
+Stories that were already imported are skipped, so the import can be
+run again safely. Timestamps and vocabulary encounter counts are kept;
+missing or unreadable timestamps are left empty.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if _, err := os.Stat(args[0]); err != nil {
+			return err
+		}
+
+		store, userID, err := db.OpenLocal(cfgManager.DatabaseFile())
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		summary, err := store.ImportLegacy(args[0], userID)
+		if err != nil {
+			return fmt.Errorf("import failed, nothing was changed: %w", err)
+		}
+
+		fmt.Printf("✅ Imported %s\n", args[0])
+		fmt.Printf("   📖 Stories:    %d imported, %d duplicates skipped\n", summary.Stories, summary.DuplicateStories)
+		fmt.Printf("   📚 Vocabulary: %d new words, %d merged into existing words\n", summary.NewVocabulary, summary.MergedVocabulary)
+		fmt.Printf("   💪 Exercises:  %d imported, %d with answers\n", summary.Exercises, summary.AnsweredExercises)
+		fmt.Printf("   🗓️ Sessions:   %d imported\n", summary.Sessions)
+		if summary.UnknownTimestamps > 0 {
+			fmt.Printf("   ⚠️ %d missing or unreadable timestamps left empty\n", summary.UnknownTimestamps)
+		}
+		return nil
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importLegacyDBCmd)
+	rootCmd.AddCommand(importCmd)
+}
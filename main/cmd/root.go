@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +28,14 @@ language learning exercises. Perfect for Russian, Urdu, and English learners.`,
 			os.Exit(1)
 		}
 	},
+	Run: func(cmd *cobra.Command, args []string) {
+		// Without a subcommand, behave like `polyglot start`
+		app := ui.NewApp(cfgManager)
+		if err := app.Run(); err != nil {
+			fmt.Printf("Error running application: %v\n", err)
+			os.Exit(1)
+		}
+	},
 }
 
 func Execute() {
@@ -37,5 +46,7 @@ func Execute() {
 }
 
 func init() {
+	// Execute prints the error itself
+	rootCmd.SilenceErrors = true
 	rootCmd.SetVersionTemplate("Polyglot AI Storyteller {{.Version}}\n")
 }
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var (
+	vocabOutput string
+	vocabFilter db.VocabFilter
+	vocabAll    bool
+
+	vocabExample     string
+	vocabTranslation string
+	vocabTags        []string
+	vocabLevel       string
+	vocabUnset       bool
+)
+
+var vocabCmd = &cobra.Command{
+	Use:   "vocab",
+	Short: "Browse and edit the vocabulary notebook",
+	Long: `Browse, search and edit the vocabulary collected from your stories.
+
+Words marked as known are hidden from listings unless --all is given,
+skipped in reviews, and left out of future story prompts.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		rootCmd.PersistentPreRun(cmd, args)
+		cmd.SilenceUsage = true
+		if vocabOutput != "text" && vocabOutput != "json" {
+			return fmt.Errorf("invalid output format %q (use text or json)", vocabOutput)
+		}
+		return cfgManager.LoadLanguages()
+	},
+}
+
+var vocabListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List vocabulary, optionally filtered",
+	Example: `  polyglot vocab list --language russian --level A2
+  polyglot vocab list --tag food --all -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listVocabulary()
+	},
+}
+
+var vocabSearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search words and translations",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vocabFilter.Search = strings.Join(args, " ")
+		return listVocabulary()
+	},
+}
+
+var vocabAddCmd = &cobra.Command{
+	Use:     "add <word> <translation>",
+	Short:   "Add your own word",
+	Example: `  polyglot vocab add кошка cat --language russian --tag animals`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := cfgManager.Load()
+		if err != nil {
+			return err
+		}
+
+		entry := db.VocabEntry{
+			Language:    cfg.Language,
+			Word:        args[0],
+			Translation: args[1],
+			Example:     vocabExample,
+			Level:       cfg.Level,
+			Tags:        vocabTags,
+		}
+		if vocabFilter.Language != "" {
+			entry.Language = vocabFilter.Language
+		}
+		if vocabLevel != "" {
+			entry.Level = strings.ToUpper(vocabLevel)
+		}
+		if _, ok := config.Languages[entry.Language]; !ok {
+			return fmt.Errorf("unknown language %q", entry.Language)
+		}
+		if _, ok := config.Levels[entry.Level]; !ok {
+			return fmt.Errorf("unknown level %q", entry.Level)
+		}
+
+		store, _, err := db.OpenLocal(cfgManager.DatabaseFile())
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		id, err := store.AddVocabulary(entry)
+		if err != nil {
+			return err
+		}
+		return printVocabEntry(store, id, "✅ Added")
+	},
+}
+
+var vocabEditCmd = &cobra.Command{
+	Use:     "edit <id>",
+	Short:   "Fix the translation or example of a word",
+	Example: `  polyglot vocab edit 42 --translation "to go (on foot)"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := parseVocabID(args[0])
+		if err != nil {
+			return err
+		}
+		if vocabTranslation == "" && vocabExample == "" {
+			return fmt.Errorf("nothing to change, use --translation or --example")
+		}
+
+		store, _, err := db.OpenLocal(cfgManager.DatabaseFile())
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		if err := store.UpdateVocabulary(id, vocabTranslation, vocabExample); err != nil {
+			return err
+		}
+		return printVocabEntry(store, id, "✅ Updated")
+	},
+}
+
+var vocabKnownCmd = &cobra.Command{
+	Use:   "known <id>...",
+	Short: "Mark words as known (or not known with --unset)",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, _, err := db.OpenLocal(cfgManager.DatabaseFile())
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		for _, arg := range args {
+			id, err := parseVocabID(arg)
+			if err != nil {
+				return err
+			}
+			if err := store.SetKnown(id, !vocabUnset); err != nil {
+				return err
+			}
+			if err := printVocabEntry(store, id, "✅ Updated"); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var vocabTagCmd = &cobra.Command{
+	Use:     "tag <id> <tag>...",
+	Short:   "Add tags to a word (or remove them with --remove)",
+	Example: `  polyglot vocab tag 42 verbs motion`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := parseVocabID(args[0])
+		if err != nil {
+			return err
+		}
+
+		store, _, err := db.OpenLocal(cfgManager.DatabaseFile())
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		for _, tag := range args[1:] {
+			if vocabUnset {
+				err = store.RemoveTag(id, tag)
+			} else {
+				err = store.AddTag(id, tag)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return printVocabEntry(store, id, "✅ Updated")
+	},
+}
+
+func listVocabulary() error {
+	store, _, err := db.OpenLocal(cfgManager.DatabaseFile())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	vocabFilter.IncludeKnown = vocabAll
+	vocabFilter.Level = strings.ToUpper(vocabFilter.Level)
+	entries, err := store.ListVocabulary(vocabFilter)
+	if err != nil {
+		return err
+	}
+
+	if vocabOutput == "json" {
+		if entries == nil {
+			entries = []db.VocabEntry{}
+		}
+		return printJSON(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No words match")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tWORD\tTRANSLATION\tLANGUAGE\tLEVEL\tSEEN\tTAGS\tKNOWN")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\t%s\t%v\n", entry.ID, entry.Word, entry.Translation,
+			entry.Language, entry.Level, entry.TimesEncountered, strings.Join(entry.Tags, ","), entry.Known)
+	}
+	return w.Flush()
+}
+
+func printVocabEntry(store *db.DB, id int64, status string) error {
+	entry, err := store.GetVocabulary(id)
+	if err != nil {
+		return err
+	}
+
+	if vocabOutput == "json" {
+		return printJSON(entry)
+	}
+	fmt.Printf("%s #%d: %s - %s", status, entry.ID, entry.Word, entry.Translation)
+	if len(entry.Tags) > 0 {
+		fmt.Printf(" [%s]", strings.Join(entry.Tags, ", "))
+	}
+	if entry.Known {
+		fmt.Print(" (known)")
+	}
+	fmt.Println()
+	return nil
+}
+
+func parseVocabID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid vocabulary id %q", arg)
+	}
+	return id, nil
+}
+
+func init() {
+	vocabCmd.PersistentFlags().StringVarP(&vocabOutput, "output", "o", "text", "output format: text or json")
+
+	for _, c := range []*cobra.Command{vocabListCmd, vocabSearchCmd} {
+		c.Flags().StringVarP(&vocabFilter.Language, "language", "l", "", "only this language")
+		c.Flags().StringVar(&vocabFilter.Level, "level", "", "only this CEFR level")
+		c.Flags().Int64Var(&vocabFilter.StoryID, "story", 0, "only words from this story id")
+		c.Flags().StringVarP(&vocabFilter.Tag, "tag", "t", "", "only words with this tag")
+		c.Flags().BoolVarP(&vocabAll, "all", "a", false, "include words marked as known")
+	}
+	vocabListCmd.Flags().StringVarP(&vocabFilter.Search, "search", "s", "", "match word or translation")
+
+	vocabAddCmd.Flags().StringVarP(&vocabFilter.Language, "language", "l", "", "language of the word (default: current language)")
+	vocabAddCmd.Flags().StringVar(&vocabLevel, "level", "", "CEFR level (default: current level)")
+	vocabAddCmd.Flags().StringVarP(&vocabExample, "example", "e", "", "example sentence")
+	vocabAddCmd.Flags().StringSliceVarP(&vocabTags, "tag", "t", nil, "tags, repeat or separate with commas")
+
+	vocabEditCmd.Flags().StringVar(&vocabTranslation, "translation", "", "corrected translation")
+	vocabEditCmd.Flags().StringVarP(&vocabExample, "example", "e", "", "corrected example sentence")
+
+	vocabKnownCmd.Flags().BoolVar(&vocabUnset, "unset", false, "mark as not known")
+	vocabTagCmd.Flags().BoolVar(&vocabUnset, "remove", false, "remove the tags instead")
+
+	vocabCmd.AddCommand(vocabListCmd, vocabSearchCmd, vocabAddCmd, vocabEditCmd, vocabKnownCmd, vocabTagCmd)
+	rootCmd.AddCommand(vocabCmd)
+}
@@ -0,0 +1,90 @@
+// Command first-letter is a sample exercise plugin. For every vocabulary
+// word of a story it asks for the word given its translation and first
+// letter.
+//
+// Build it into the plugins directory to try it:
+//
+//	go build -o ~/.local/share/polyglot-stories/plugins/first-letter ./examples/plugins/first-letter
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type exercise struct {
+	Type     string `json:"type"`
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+type request struct {
+	Type  string `json:"type"`
+	Story struct {
+		Vocabulary []struct {
+			Word        string `json:"word"`
+			Translation string `json:"translation"`
+		} `json:"vocabulary"`
+	} `json:"story"`
+	Exercise exercise `json:"exercise"`
+	Answer   string   `json:"answer"`
+}
+
+type response struct {
+	Error         string     `json:"error,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	ExerciseTypes []string   `json:"exercise_types,omitempty"`
+	Exercises     []exercise `json:"exercises,omitempty"`
+	Correct       bool       `json:"correct"`
+	Explanation   string     `json:"explanation,omitempty"`
+}
+
+func main() {
+	var req request
+	line, _ := bufio.NewReader(os.Stdin).ReadBytes('\n')
+	if err := json.Unmarshal(line, &req); err != nil {
+		reply(response{Error: "invalid request: " + err.Error()})
+		return
+	}
+
+	switch req.Type {
+	case "describe":
+		reply(response{
+			Name:          "first-letter",
+			Description:   "Recall story words from their translation and first letter",
+			ExerciseTypes: []string{"first_letter"},
+		})
+	case "generate":
+		var exercises []exercise
+		for _, v := range req.Story.Vocabulary {
+			first := []rune(v.Word)
+			if len(first) == 0 {
+				continue
+			}
+			exercises = append(exercises, exercise{
+				Type:     "first_letter",
+				Question: fmt.Sprintf("%q starts with %c…", v.Translation, first[0]),
+				Answer:   v.Word,
+			})
+		}
+		reply(response{Exercises: exercises})
+	case "grade":
+		given := strings.TrimSpace(req.Answer)
+		if strings.EqualFold(given, req.Exercise.Answer) {
+			reply(response{Correct: true})
+			return
+		}
+		reply(response{Explanation: fmt.Sprintf("The word is %s (%d letters)",
+			req.Exercise.Answer, len([]rune(req.Exercise.Answer)))})
+	default:
+		reply(response{Error: "unknown request type " + req.Type})
+	}
+}
+
+func reply(resp response) {
+	json.NewEncoder(os.Stdout).Encode(resp)
+}
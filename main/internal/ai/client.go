@@ -6,9 +6,20 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
 )
 
+// maxStoryAttempts bounds how often a story is regenerated when it misses
+// the level constraints.
+const maxStoryAttempts = 2
+
+// maxKnownWordsInPrompt keeps the prompt short for learners with large
+// notebooks; known words beyond it are still filtered from the result.
+const maxKnownWordsInPrompt = 200
+
 type Client struct {
 	endpoint string
 	model    string
@@ -40,16 +51,38 @@ type StoryResponse struct {
 }
 
 type Vocabulary struct {
-	Word        string `json:"word"`
-	Translation string `json:"translation"`
-	Example     string `json:"example"`
+	Word               string   `json:"word"`
+	Translation        string   `json:"translation"`
+	Example            string   `json:"example"`
+	ExampleTranslation string   `json:"example_translation"`
+	PartOfSpeech       string   `json:"part_of_speech"`
+	Lemma              string   `json:"lemma"`
+	Features           Features `json:"features"`
+	Pronunciation      string   `json:"pronunciation"`
 }
 
 type Exercise struct {
-	Type     string   `json:"type"`
-	Question string   `json:"question"`
-	Answer   string   `json:"answer"`
-	Options  []string `json:"options"`
+	Type         string   `json:"type"`
+	Question     string   `json:"question"`
+	Answer       string   `json:"answer"`
+	Alternatives []string `json:"alternatives"`
+	Options      []string `json:"options"`
+	Hints        []string `json:"hints"`
+}
+
+// AnswerOption returns the index of the option matching the answer, or -1.
+func (e Exercise) AnswerOption(lang config.Language) int {
+	for i, option := range e.Options {
+		if lang.NormalizeAnswer(option) == lang.NormalizeAnswer(e.Answer) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Accepted returns the answer followed by its accepted alternatives.
+func (e Exercise) Accepted() []string {
+	return append([]string{e.Answer}, e.Alternatives...)
 }
 
 func NewClient() *Client {
@@ -60,36 +93,115 @@ func NewClient() *Client {
 	}
 }
 
-func (c *Client) GenerateStory(language, level, topic string) (*StoryResponse, error) {
-	prompt := fmt.Sprintf(`Create an engaging %s story for %s language learners about %s. 
+func (c *Client) Model() string {
+	return c.model
+}
+
+func (c *Client) GenerateStory(lang config.Language, level config.Level, topic string, knownWords []string) (*StoryResponse, error) {
+	if _, ok := config.Levels[level.Code]; !ok {
+		return nil, fmt.Errorf("unknown level %q", level.Code)
+	}
+
+	constraints := level.Constraints
+	basePrompt := fmt.Sprintf(`Create an engaging %s story for %s (CEFR %s) language learners about %s.
+Follow these level constraints:
+- about %d words in total
+- no sentence longer than %d words
+- use only these tenses: %s
+- use vocabulary from %s
+
 Provide the response as valid JSON with these exact fields:
 - story_text: the story in %s
 - translation: English translation
-- vocabulary: array of objects with word, translation, example
-- exercises: array of objects with type, question, answer, options
+- vocabulary: array of objects with word, translation, example (a short sentence that uses the word exactly as written), example_translation (its English translation), part_of_speech, lemma (the dictionary form), features (an object with %s where they apply) and pronunciation (IPA)
+- exercises: array of objects with type, question, answer, alternatives (other correct answers, such as synonyms or word order variants), options and hints (two to four English hints from vague to revealing that never contain the answer itself)
+
+Exercise types:
+- multiple_choice: options has 3 or 4 choices, one written exactly as the answer
+- fill_blank: question is a sentence from the story with ___ in place of the answer
+- true_false: question is a statement about the story, answer is "true" or "false"
+- matching: options are 3 to 5 pairs written "word = translation"
+- translation: question is a sentence to translate, answer is its translation
+- word_order: answer is a short sentence from the story
+
+Make sure the JSON is valid and properly formatted. Return ONLY the JSON without any additional text or markdown code blocks.`,
+		lang.Name, level.Name, level.Code, topic,
+		constraints.WordCount, constraints.MaxSentenceLength, strings.Join(constraints.Tenses, ", "), constraints.FrequencyBand,
+		lang.Name, grammarFeatures(lang))
+
+	if len(knownWords) > 0 {
+		listed := knownWords
+		if len(listed) > maxKnownWordsInPrompt {
+			listed = listed[:maxKnownWordsInPrompt]
+		}
+		basePrompt += "\n\nThe learner already knows these words, so leave them out of the vocabulary list: " + strings.Join(listed, ", ")
+	}
 
-Make sure the JSON is valid and properly formatted. Return ONLY the JSON without any additional text or markdown code blocks.`, language, level, topic, language)
+	// First try with AI, asking for a rewrite when the story misses the constraints
+	var best *StoryResponse
+	bestIssues := 0
+	prompt := basePrompt
+	for attempt := 0; attempt < maxStoryAttempts; attempt++ {
+		aiResponse, err := c.callAI(prompt)
+		if err != nil || aiResponse == "" {
+			break
+		}
 
-	// First try with AI
-	aiResponse, err := c.callAI(prompt)
-	if err == nil && aiResponse != "" {
 		var story StoryResponse
-		if err := json.Unmarshal([]byte(aiResponse), &story); err == nil {
-			return &story, nil
+		if err := json.Unmarshal([]byte(aiResponse), &story); err != nil {
+			continue
 		}
+
+		issues := ValidateStory(&story, lang, level)
+		if best == nil || len(issues) < bestIssues {
+			best, bestIssues = &story, len(issues)
+		}
+		if len(issues) == 0 {
+			break
+		}
+		prompt = basePrompt + "\n\nYour previous story broke these constraints:\n- " + strings.Join(issues, "\n- ") + "\nWrite a new story that follows them."
+	}
+	if best != nil {
+		best.Vocabulary = withoutKnown(best.Vocabulary, knownWords)
+		if len(best.Exercises) == 0 {
+			best.Exercises = LocalExercises(best, lang)
+		}
+		return best, nil
 	}
 
-	// Fallback story
-	return &StoryResponse{
-		StoryText:   fmt.Sprintf("Welcome to your %s lesson about %s. This is a sample story for learning.", language, topic),
-		Translation: "Welcome to your language lesson. This is a sample story for learning.",
+	// Fallback story, in English and so its own translation
+	sample := fmt.Sprintf("Welcome to your %s lesson about %s. This is a sample story for learning.", lang.Name, topic)
+	fallback := &StoryResponse{
+		StoryText:   sample,
+		Translation: sample,
 		Vocabulary: []Vocabulary{
 			{Word: "welcome", Translation: "greeting", Example: "Welcome to the lesson."},
 		},
-		Exercises: []Exercise{
-			{Type: "multiple_choice", Question: "What is this story about?", Answer: "learning", Options: []string{"learning", "working", "playing"}},
-		},
-	}, nil
+	}
+	fallback.Exercises = LocalExercises(fallback, lang)
+	return fallback, nil
+}
+
+func grammarFeatures(lang config.Language) string {
+	if len(lang.GrammarFeatures) == 0 {
+		return "the grammatical features a learner needs"
+	}
+	return strings.Join(lang.GrammarFeatures, ", ")
+}
+
+func withoutKnown(vocabulary []Vocabulary, knownWords []string) []Vocabulary {
+	known := map[string]bool{}
+	for _, word := range knownWords {
+		known[strings.ToLower(word)] = true
+	}
+
+	var filtered []Vocabulary
+	for _, vocab := range vocabulary {
+		if !known[strings.ToLower(vocab.Word)] && !known[strings.ToLower(vocab.Lemma)] {
+			filtered = append(filtered, vocab)
+		}
+	}
+	return filtered
 }
 
 func (c *Client) callAI(prompt string) (string, error) {
@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Features are a word's grammatical features, such as case or aspect.
+// Models don't always return plain strings for them, so other values are
+// turned into text instead of failing the whole story.
+type Features map[string]string
+
+func (f *Features) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw) == 0 {
+		// Empty or not an object: drop the features, keep the story
+		*f = nil
+		return nil
+	}
+
+	features := Features{}
+	for name, value := range raw {
+		if text := featureText(value); name != "" && text != "" {
+			features[name] = text
+		}
+	}
+	*f = features
+	return nil
+}
+
+// featureText renders a JSON value as text: arrays as a comma-separated
+// list and objects as "key: value" pairs in key order.
+func featureText(value json.RawMessage) string {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 {
+		return ""
+	}
+
+	switch value[0] {
+	case '"':
+		var s string
+		json.Unmarshal(value, &s)
+		return strings.TrimSpace(s)
+	case '[':
+		var items []json.RawMessage
+		json.Unmarshal(value, &items)
+		var parts []string
+		for _, item := range items {
+			if text := featureText(item); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case '{':
+		var fields map[string]json.RawMessage
+		json.Unmarshal(value, &fields)
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var parts []string
+		for _, name := range names {
+			if text := featureText(fields[name]); text != "" {
+				parts = append(parts, name+": "+text)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case 'n':
+		return ""
+	default:
+		// Numbers and booleans as written
+		return string(value)
+	}
+}
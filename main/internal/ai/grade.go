@@ -0,0 +1,80 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+)
+
+// Grade is the model's verdict on a free-text answer.
+type Grade struct {
+	Score       int    `json:"score"`
+	Acceptable  bool   `json:"acceptable"`
+	Explanation string `json:"explanation"`
+}
+
+// GradeAnswer asks the model to grade a free-text answer against the
+// reference answer, explaining its verdict in the learner's native language.
+func (c *Client) GradeAnswer(lang config.Language, nativeLanguage, question, reference, given string) (*Grade, error) {
+	prompt := fmt.Sprintf(`You are grading a %s language learner's answer to an exercise.
+
+Question: %s
+Reference answer: %s
+Learner's answer: %s
+
+Judge whether the learner's answer means the same as the reference answer and is grammatically correct. Accept synonyms, different word order and other valid phrasings.
+
+Provide the response as valid JSON with these exact fields:
+- score: a whole number from 0 to 100
+- acceptable: true if the answer should count as correct
+- explanation: one or two short sentences in %s about what is right or wrong
+
+Return ONLY the JSON without any additional text or markdown code blocks.`,
+		lang.Name, question, reference, given, nativeLanguage)
+
+	content, err := c.callAI(prompt)
+	if err != nil {
+		return nil, fmt.Errorf("grading request failed: %w", err)
+	}
+	if content == "" {
+		return nil, errors.New("empty grading response")
+	}
+
+	var grade Grade
+	if err := json.Unmarshal([]byte(content), &grade); err != nil {
+		return nil, fmt.Errorf("invalid grading response: %w", err)
+	}
+	grade.Score = min(max(grade.Score, 0), 100)
+	return &grade, nil
+}
+
+// ExplainMistake asks the model why the learner's answer is wrong, focusing
+// on the grammar or vocabulary point involved. sentence is the story
+// sentence the exercise is about and may be empty.
+func (c *Client) ExplainMistake(lang config.Language, nativeLanguage, sentence, question, expected, given string) (string, error) {
+	context := ""
+	if sentence != "" {
+		context = fmt.Sprintf("Sentence from the story: %s\n", sentence)
+	}
+	prompt := fmt.Sprintf(`A %s language learner answered an exercise incorrectly.
+
+%sQuestion: %s
+Expected answer: %s
+Learner's answer: %s
+
+Explain the mistake in two to four short sentences in %s. Focus on the one grammar or vocabulary point that matters, such as a case ending, verb aspect or a word confused with another. Return only the explanation as plain text without markdown.`,
+		lang.Name, context, question, expected, given, nativeLanguage)
+
+	content, err := c.callAI(prompt)
+	if err != nil {
+		return "", fmt.Errorf("explanation request failed: %w", err)
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", errors.New("empty explanation")
+	}
+	return content, nil
+}
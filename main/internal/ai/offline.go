@@ -0,0 +1,145 @@
+package ai
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// Limits for the exercises built without the AI.
+const (
+	maxLocalCloze     = 3
+	maxLocalWordOrder = 2
+	maxLocalPairs     = 5
+	minOrderWords     = 3
+	maxOrderWords     = 8
+	minClozeWordRunes = 4
+)
+
+// LocalExercises builds exercises from the story text and vocabulary
+// without calling the AI: cloze deletions of vocabulary words, sentences to
+// put back in order and a vocabulary matching. The same story always gives
+// the same exercises.
+func LocalExercises(story *StoryResponse, lang config.Language) []Exercise {
+	sentences := story.Sentences(lang)
+	translations := story.TranslatedSentences(lang)
+
+	exercises := clozeExercises(sentences, story.Vocabulary)
+	exercises = append(exercises, wordOrderExercises(sentences, translations)...)
+	if matching, ok := matchingExercise(story.Vocabulary); ok {
+		exercises = append(exercises, matching)
+	}
+	return exercises
+}
+
+// clozeExercises blanks vocabulary words in the first sentence using them.
+// Without vocabulary in the story, the longest word of a sentence is used.
+func clozeExercises(sentences []string, vocabulary []Vocabulary) []Exercise {
+	var exercises []Exercise
+	used := map[int]bool{}
+
+	for _, vocab := range vocabulary {
+		if len(exercises) == maxLocalCloze {
+			return exercises
+		}
+		for i, sentence := range sentences {
+			if used[i] {
+				continue
+			}
+			// Answer with the word as written, capitalised at a sentence start
+			if word, ok := findWord(sentence, vocab.Word); ok {
+				question, _ := text.Blank(sentence, word, "___")
+				exercises = append(exercises, Exercise{Type: "fill_blank", Question: question, Answer: word})
+				used[i] = true
+				break
+			}
+		}
+	}
+	if len(exercises) > 0 {
+		return exercises
+	}
+
+	for _, sentence := range sentences {
+		if len(exercises) == maxLocalCloze {
+			break
+		}
+		word := longestWord(sentence)
+		if utf8.RuneCountInString(word) < minClozeWordRunes {
+			continue
+		}
+		if question, ok := text.Blank(sentence, word, "___"); ok {
+			exercises = append(exercises, Exercise{Type: "fill_blank", Question: question, Answer: word})
+		}
+	}
+	return exercises
+}
+
+// findWord returns the first occurrence of a word or phrase in sentence as
+// written there.
+func findWord(sentence, word string) (string, bool) {
+	found := ""
+	text.ReplacePhrases(sentence, []string{word}, func(match string) string {
+		if found == "" {
+			found = match
+		}
+		return match
+	})
+	return found, found != ""
+}
+
+func longestWord(sentence string) string {
+	longest := ""
+	for _, word := range text.Words(sentence) {
+		if utf8.RuneCountInString(word) > utf8.RuneCountInString(longest) {
+			longest = word
+		}
+	}
+	return longest
+}
+
+// wordOrderExercises asks for short sentences to be rebuilt from their
+// words, prompted by the translation when there is one. Sentences their
+// translation would give away are left out.
+func wordOrderExercises(sentences, translations []string) []Exercise {
+	var exercises []Exercise
+	for i, sentence := range sentences {
+		if len(exercises) == maxLocalWordOrder {
+			break
+		}
+		if words := len(text.Words(sentence)); words < minOrderWords || words > maxOrderWords {
+			continue
+		}
+		exercise := Exercise{Type: "word_order", Answer: sentence}
+		if translations != nil {
+			exercise.Question = translations[i]
+		}
+		if strings.EqualFold(strings.TrimSpace(exercise.Question), strings.TrimSpace(sentence)) {
+			continue
+		}
+		exercises = append(exercises, exercise)
+	}
+	return exercises
+}
+
+// matchingExercise pairs vocabulary words with their translations.
+func matchingExercise(vocabulary []Vocabulary) (Exercise, bool) {
+	var pairs []string
+	seen := map[string]bool{}
+	for _, vocab := range vocabulary {
+		if len(pairs) == maxLocalPairs {
+			break
+		}
+		word, translation := strings.TrimSpace(vocab.Word), strings.TrimSpace(vocab.Translation)
+		if word == "" || translation == "" || strings.Contains(word+translation, "=") || seen[word] {
+			continue
+		}
+		seen[word] = true
+		pairs = append(pairs, word+" = "+translation)
+	}
+	if len(pairs) < 2 {
+		return Exercise{}, false
+	}
+	return Exercise{Type: "matching", Options: pairs}, true
+}
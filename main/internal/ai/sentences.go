@@ -0,0 +1,41 @@
+package ai
+
+import (
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// translationDelimiters end sentences of the English translation.
+const translationDelimiters = ".!?"
+
+// Sentences splits the story text into sentences.
+func (s *StoryResponse) Sentences(lang config.Language) []string {
+	return text.Sentences(s.StoryText, lang.SentenceDelimiters)
+}
+
+// TranslatedSentences splits the translation into sentences, or returns nil
+// when they don't line up one to one with the story's.
+func (s *StoryResponse) TranslatedSentences(lang config.Language) []string {
+	translations := text.Sentences(s.Translation, translationDelimiters)
+	if len(translations) != len(s.Sentences(lang)) {
+		return nil
+	}
+	return translations
+}
+
+// SentenceWith returns the index of the first story sentence containing
+// phrase after normalization, or -1.
+func (s *StoryResponse) SentenceWith(lang config.Language, phrase string) int {
+	wanted := lang.NormalizeAnswer(phrase)
+	if wanted == "" {
+		return -1
+	}
+	for i, sentence := range s.Sentences(lang) {
+		if strings.Contains(" "+lang.NormalizeAnswer(sentence)+" ", " "+wanted+" ") {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,40 @@
+package ai
+
+import (
+	"fmt"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// ValidateStory checks a generated story against the level constraints and
+// its exercises for answers missing from their options, and describes every
+// violation it finds. Tenses and vocabulary band are left to
+// the model since they cannot be checked without a grammar.
+func ValidateStory(story *StoryResponse, lang config.Language, level config.Level) []string {
+	var issues []string
+	constraints := level.Constraints
+
+	wordCount := len(text.Words(story.StoryText))
+	if wordCount < constraints.WordCount/2 || wordCount > constraints.WordCount*3/2 {
+		issues = append(issues, fmt.Sprintf("story has %d words, expected about %d", wordCount, constraints.WordCount))
+	}
+
+	for i, sentence := range text.Sentences(story.StoryText, lang.SentenceDelimiters) {
+		if length := len(text.Words(sentence)); length > constraints.MaxSentenceLength {
+			issues = append(issues, fmt.Sprintf("sentence %d has %d words, maximum is %d", i+1, length, constraints.MaxSentenceLength))
+		}
+	}
+
+	for i, exercise := range story.Exercises {
+		// Matching pairs and word order tiles are not choices
+		if exercise.Type == "matching" || exercise.Type == "word_order" {
+			continue
+		}
+		if len(exercise.Options) > 0 && exercise.AnswerOption(lang) < 0 {
+			issues = append(issues, fmt.Sprintf("exercise %d answer %q is not one of its options", i+1, exercise.Answer))
+		}
+	}
+
+	return issues
+}
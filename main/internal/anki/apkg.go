@@ -0,0 +1,263 @@
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// modelID identifies the note type. It must never change, or Anki treats
+// re-exported notes as a different type.
+const modelID = 1700000000001
+
+// modelName is the note type's name, also used by the text export.
+const modelName = "Polyglot Vocabulary"
+
+const collectionSchema = `
+CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null,
+    ver integer not null, dty integer not null, usn integer not null, ls integer not null, conf text not null,
+    models text not null, decks text not null, dconf text not null, tags text not null);
+CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null,
+    usn integer not null, tags text not null, flds text not null, sfld integer not null, csum integer not null,
+    flags integer not null, data text not null);
+CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null, ord integer not null,
+    mod integer not null, usn integer not null, type integer not null, queue integer not null, due integer not null,
+    ivl integer not null, factor integer not null, reps integer not null, lapses integer not null, left integer not null,
+    odue integer not null, odid integer not null, flags integer not null, data text not null);
+CREATE TABLE revlog (id integer primary key, cid integer not null, usn integer not null, ease integer not null,
+    ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null, type integer not null);
+CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+// templates are the card types of the note type: one card per direction.
+var templates = []struct{ name, front, back string }{
+	{"Recognition", `<div class="word">{{Word}}</div>`,
+		`{{FrontSide}}<hr id="answer"><div>{{Translation}}</div>{{#Example}}<div class="example">{{Example}}</div>{{/Example}}{{#Context}}<div class="context">{{Context}}</div>{{/Context}}`},
+	{"Recall", `<div>{{Translation}}</div>`,
+		`{{FrontSide}}<hr id="answer"><div class="word">{{Word}}</div>{{#Example}}<div class="example">{{Example}}</div>{{/Example}}{{#Context}}<div class="context">{{Context}}</div>{{/Context}}`},
+}
+
+const css = `.card { font-family: sans-serif; font-size: 22px; text-align: center; }
+.word { font-size: 32px; }
+.example, .context { font-size: 18px; color: #666; margin-top: 12px; }`
+
+// WriteAPKG writes notes as an Anki package. Notes and cards keep their ids
+// and GUIDs across exports, so importing a newer package updates them.
+func WriteAPKG(path string, notes []Note) error {
+	tmpDir, err := os.MkdirTemp("", "polyglot-anki")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	collection := filepath.Join(tmpDir, "collection.anki2")
+	if err := writeCollection(collection, notes); err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	archive := zip.NewWriter(out)
+	if err := addFile(archive, "collection.anki2", collection); err != nil {
+		return err
+	}
+	media, err := archive.Create("media")
+	if err != nil {
+		return err
+	}
+	if _, err := media.Write([]byte("{}")); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func addFile(archive *zip.Writer, name, path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, in)
+	return err
+}
+
+func writeCollection(path string, notes []Note) error {
+	conn, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Exec(collectionSchema); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	decks := map[string]int64{}
+	for _, note := range notes {
+		decks[note.Deck()] = stableID("deck\x1f" + note.Deck())
+	}
+
+	models, decksJSON, dconf, conf, err := collectionJSON(now, decks, len(notes)+1)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		now.Unix(), now.UnixMilli(), now.UnixMilli(), conf, models, decksJSON, dconf); err != nil {
+		return err
+	}
+
+	for i, note := range notes {
+		if _, err := tx.Exec(`INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			note.ID, note.GUID, modelID, now.Unix(), " "+strings.Join(note.Tags, " ")+" ",
+			strings.Join(htmlFields(note.Fields), "\x1f"), note.Fields[0], checksum(note.Fields[0])); err != nil {
+			return err
+		}
+		for ord := range templates {
+			cardID := stableID("card\x1f" + note.GUID + "\x1f" + templates[ord].name)
+			if _, err := tx.Exec(`INSERT INTO cards VALUES (?, ?, ?, ?, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+				cardID, note.ID, decks[note.Deck()], ord, now.Unix(), i+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// htmlFields escapes field values, which Anki renders as HTML. The sort
+// field and checksum stay plain text, as Anki strips HTML from them.
+func htmlFields(fields []string) []string {
+	escaped := make([]string, len(fields))
+	for i, field := range fields {
+		escaped[i] = html.EscapeString(field)
+	}
+	return escaped
+}
+
+func collectionJSON(now time.Time, decks map[string]int64, nextPos int) (models, decksJSON, dconf, conf string, err error) {
+	var fields []map[string]interface{}
+	for i, name := range Fields {
+		fields = append(fields, map[string]interface{}{
+			"name": name, "ord": i, "sticky": false, "rtl": false,
+			"font": "Arial", "size": 20, "media": []string{},
+		})
+	}
+
+	var tmpls []map[string]interface{}
+	for i, t := range templates {
+		tmpls = append(tmpls, map[string]interface{}{
+			"name": t.name, "ord": i, "qfmt": t.front, "afmt": t.back,
+			"did": nil, "bqfmt": "", "bafmt": "",
+		})
+	}
+	// Recognition needs Word, Recall needs Translation
+	req := []interface{}{[]interface{}{0, "any", []int{0}}, []interface{}{1, "any", []int{1}}}
+
+	var defaultDeck int64
+	deckMap := map[string]interface{}{
+		"1": deckJSON(1, "Default", now),
+	}
+	for name, id := range decks {
+		deckMap[jsonID(id)] = deckJSON(id, name, now)
+		defaultDeck = id
+	}
+	if defaultDeck == 0 {
+		defaultDeck = 1
+	}
+
+	modelMap := map[string]interface{}{
+		jsonID(modelID): map[string]interface{}{
+			"id": modelID, "name": modelName, "type": 0, "mod": now.Unix(), "usn": -1,
+			"sortf": 0, "did": defaultDeck, "tmpls": tmpls, "flds": fields, "css": css,
+			"latexPre": "", "latexPost": "", "tags": []string{}, "vers": []string{}, "req": req,
+		},
+	}
+
+	dconfMap := map[string]interface{}{
+		"1": map[string]interface{}{
+			"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0,
+			"replayq": true, "dyn": false,
+			"new": map[string]interface{}{"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": 2500,
+				"order": 1, "perDay": 20, "bury": false, "separate": true},
+			"rev": map[string]interface{}{"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "maxIvl": 36500,
+				"ivlFct": 1, "bury": false, "minSpace": 1},
+			"lapse": map[string]interface{}{"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": 8,
+				"leechAction": 0},
+		},
+	}
+
+	confMap := map[string]interface{}{
+		"nextPos": nextPos, "estTimes": true, "activeDecks": []int{1}, "sortType": "noteFld", "timeLim": 0,
+		"sortBackwards": false, "addToCur": true, "curDeck": 1, "newSpread": 0, "dueCounts": true,
+		"curModel": jsonID(modelID), "collapseTime": 1200,
+	}
+
+	for _, item := range []struct {
+		value interface{}
+		out   *string
+	}{{modelMap, &models}, {deckMap, &decksJSON}, {dconfMap, &dconf}, {confMap, &conf}} {
+		data, err := json.Marshal(item.value)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		*item.out = string(data)
+	}
+	return models, decksJSON, dconf, conf, nil
+}
+
+func deckJSON(id int64, name string, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"id": id, "name": name, "mod": now.Unix(), "usn": -1, "desc": "", "dyn": 0, "conf": 1,
+		"collapsed": false, "extendNew": 10, "extendRev": 50,
+		"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+	}
+}
+
+func jsonID(id int64) string {
+	data, _ := json.Marshal(id)
+	return string(data)
+}
+
+// checksum is Anki's duplicate-detection hash: the first 8 hex digits of
+// the SHA-1 of the sort field.
+func checksum(field string) int64 {
+	sum := sha1.Sum([]byte(field))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
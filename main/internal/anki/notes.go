@@ -0,0 +1,109 @@
+package anki
+
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// Fields of the exported note type, in order.
+var Fields = []string{"Word", "Translation", "Example", "Context"}
+
+// Note is one vocabulary word ready for export. ID and GUID are derived
+// from the language and word, so exporting again updates the same notes
+// in Anki instead of adding duplicates.
+type Note struct {
+	ID       int64
+	GUID     string
+	Language string
+	Fields   []string
+	Tags     []string
+}
+
+// Deck returns the name of the Anki deck a note belongs in.
+func (n Note) Deck() string {
+	name := n.Language
+	if lang, ok := config.Languages[n.Language]; ok {
+		name = lang.Name
+	}
+	return "Polyglot::" + name
+}
+
+// BuildNotes turns notebook entries into notes. storyText looks up the text
+// of an entry's source story for the context sentence and may be nil.
+func BuildNotes(entries []db.VocabEntry, storyText func(id int64) string) []Note {
+	var notes []Note
+	for _, entry := range entries {
+		context := ""
+		if entry.StoryID != 0 && storyText != nil {
+			context = contextSentence(storyText(entry.StoryID), entry)
+		}
+
+		key := entry.Language + "\x1f" + entry.Word
+		notes = append(notes, Note{
+			ID:       stableID(key),
+			GUID:     guid(key),
+			Language: entry.Language,
+			Fields:   []string{entry.Word, entry.Translation, entry.Example, context},
+			Tags:     noteTags(entry),
+		})
+	}
+	return notes
+}
+
+func contextSentence(storyText string, entry db.VocabEntry) string {
+	delimiters := config.Languages[entry.Language].SentenceDelimiters
+	word := strings.ToLower(entry.Word)
+	for _, sentence := range text.Sentences(storyText, delimiters) {
+		if strings.Contains(strings.ToLower(sentence), word) {
+			return sentence
+		}
+	}
+	return ""
+}
+
+func noteTags(entry db.VocabEntry) []string {
+	tags := []string{"polyglot", "polyglot::" + tagValue(entry.Language)}
+	if entry.Level != "" {
+		tags = append(tags, "polyglot::level::"+tagValue(entry.Level))
+	}
+	if entry.StoryTopic != "" {
+		tags = append(tags, "polyglot::topic::"+tagValue(entry.StoryTopic))
+	}
+	for _, tag := range entry.Tags {
+		tags = append(tags, tagValue(tag))
+	}
+	return tags
+}
+
+// tagValue makes a value usable as an Anki tag, which cannot hold spaces.
+func tagValue(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), "_")
+}
+
+// stableID hashes key into a positive id that stays below 2^52 so it
+// survives Anki's JavaScript-based tools unchanged.
+func stableID(key string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return int64(h.Sum64() & (1<<52 - 1))
+}
+
+// guid encodes a hash of key with Anki's base91 alphabet.
+func guid(key string) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&()*+,-./:;<=>?@[]^_`{|}~"
+
+	h := fnv.New64a()
+	h.Write([]byte("polyglot\x1f" + key))
+	value := h.Sum64()
+
+	var encoded []byte
+	for value > 0 {
+		encoded = append(encoded, alphabet[value%uint64(len(alphabet))])
+		value /= uint64(len(alphabet))
+	}
+	return string(encoded)
+}
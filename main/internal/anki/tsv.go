@@ -0,0 +1,40 @@
+package anki
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTSV writes notes in Anki's text import format. The header lines make
+// Anki match notes by GUID and use the note type of the package export, so
+// re-importing updates existing cards.
+func WriteTSV(w io.Writer, notes []Note) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintln(out, "#separator:tab")
+	fmt.Fprintln(out, "#html:false")
+	fmt.Fprintf(out, "#notetype:%s\n", modelName)
+	fmt.Fprintln(out, "#guid column:1")
+	fmt.Fprintln(out, "#deck column:2")
+	fmt.Fprintf(out, "#tags column:%d\n", len(Fields)+3)
+	fmt.Fprintf(out, "#columns:GUID\tDeck\t%s\tTags\n", strings.Join(Fields, "\t"))
+
+	for _, note := range notes {
+		columns := []string{note.GUID, note.Deck()}
+		columns = append(columns, note.Fields...)
+		columns = append(columns, strings.Join(note.Tags, " "))
+		for i, column := range columns {
+			columns[i] = tsvEscape(column)
+		}
+		fmt.Fprintln(out, strings.Join(columns, "\t"))
+	}
+
+	return out.Flush()
+}
+
+// tsvEscape keeps a value on one line and inside its column.
+func tsvEscape(value string) string {
+	return strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,123 @@
+// Package answer grades typed answers against the accepted ones using the
+// language's normalization rules, with some tolerance for typos.
+package answer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+)
+
+// Result is how close an answer came.
+type Result int
+
+const (
+	Wrong Result = iota
+	// Almost is within the typo tolerance of an accepted answer.
+	Almost
+	Correct
+)
+
+// Accepted reports whether the answer counts towards the score. Almost
+// correct answers are pointed out but don't count.
+func (r Result) Accepted() bool {
+	return r == Correct
+}
+
+// Match describes the best match of an answer.
+type Match struct {
+	Result Result
+	// Expected is the accepted answer closest to the given one.
+	Expected string
+	Distance int
+	// Explanation and Score (0-100) are set by graders that explain and
+	// score their verdict.
+	Explanation string
+	Score       int
+}
+
+// Checker compares answers in one language.
+type Checker struct {
+	Language config.Language
+	// Tolerance is the edit distance still reported as almost correct.
+	Tolerance int
+}
+
+// Check compares given with each accepted answer after normalization.
+// Typos are only tolerated in answers of at least four letters per typo,
+// so short words can't turn into different words.
+func (c Checker) Check(given string, accepted ...string) Match {
+	normalized := c.Language.NormalizeAnswer(given)
+
+	best := Match{Result: Wrong, Distance: -1}
+	for _, answer := range accepted {
+		expected := c.Language.NormalizeAnswer(answer)
+		if expected == "" {
+			continue
+		}
+		if normalized == expected {
+			return Match{Result: Correct, Expected: answer}
+		}
+
+		distance := Distance(normalized, expected)
+		if best.Distance >= 0 && distance >= best.Distance {
+			continue
+		}
+		best = Match{Result: Wrong, Expected: answer, Distance: distance}
+		if distance <= c.Tolerance && distance*4 <= len([]rune(expected)) {
+			best.Result = Almost
+		}
+	}
+
+	if best.Expected == "" && len(accepted) > 0 {
+		best.Expected = accepted[0]
+	}
+	return best
+}
+
+// Distance is the Levenshtein distance between a and b in runes.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// Variants returns s followed by the parts of a translation like
+// "to go, to walk (on foot)" that are accepted on their own: each comma,
+// semicolon or slash separated part, with and without remarks in brackets.
+func Variants(s string) []string {
+	variants := []string{s}
+	seen := map[string]bool{s: true}
+	add := func(v string) {
+		v = strings.TrimSpace(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			variants = append(variants, v)
+		}
+	}
+
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' || r == '/' }) {
+		add(part)
+		add(brackets.ReplaceAllString(part, ""))
+	}
+	return variants
+}
+
+var brackets = regexp.MustCompile(`\s*\([^)]*\)`)
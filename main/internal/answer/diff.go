@@ -0,0 +1,75 @@
+package answer
+
+// Op is how a run of characters differs between two answers.
+type Op int
+
+const (
+	Same Op = iota
+	// Missing text is in the expected answer but not the given one.
+	Missing
+	// Extra text is in the given answer but not the expected one.
+	Extra
+)
+
+// Change is a run of characters with the same Op.
+type Change struct {
+	Op   Op
+	Text string
+}
+
+// maxDiffCells bounds the work done comparing long answers, which are then
+// shown as entirely different.
+const maxDiffCells = 1 << 20
+
+// Diff compares expected and given character by character, keeping the
+// longest common subsequence and marking the rest as missing or extra.
+func Diff(expected, given string) []Change {
+	a, b := []rune(expected), []rune(given)
+	if len(a)*len(b) > maxDiffCells {
+		return appendChange(appendChange(nil, Missing, a), Extra, b)
+	}
+
+	// lcs[i][j] is the common subsequence length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var changes []Change
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			changes = appendChange(changes, Same, a[i:i+1])
+			i, j = i+1, j+1
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			changes = appendChange(changes, Missing, a[i:i+1])
+			i++
+		default:
+			changes = appendChange(changes, Extra, b[j:j+1])
+			j++
+		}
+	}
+	return changes
+}
+
+// appendChange adds text to the last change when it has the same Op.
+func appendChange(changes []Change, op Op, text []rune) []Change {
+	if len(text) == 0 {
+		return changes
+	}
+	if n := len(changes); n > 0 && changes[n-1].Op == op {
+		changes[n-1].Text += string(text)
+		return changes
+	}
+	return append(changes, Change{Op: op, Text: string(text)})
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Field describes a user-editable setting of Config.
+type Field struct {
+	Key         string
+	Type        string
+	Description string
+	get         func(*Config) interface{}
+	set         func(*Config, string) error
+}
+
+var Fields = []Field{
+	{
+		Key:         "language",
+		Type:        "string",
+		Description: "Language stories are written in",
+		get:         func(c *Config) interface{} { return c.Language },
+		set: func(c *Config, value string) error {
+			value = strings.ToLower(value)
+			if _, ok := Languages[value]; !ok {
+				return fmt.Errorf("unknown language %q (available: %s)", value, strings.Join(LanguageKeys(), ", "))
+			}
+			c.Language = value
+			return nil
+		},
+	},
+	{
+		Key:         "level",
+		Type:        "string",
+		Description: "CEFR level of generated stories",
+		get:         func(c *Config) interface{} { return c.Level },
+		set: func(c *Config, value string) error {
+			value = strings.ToUpper(value)
+			if _, ok := Levels[value]; !ok {
+				return fmt.Errorf("unknown level %q (available: %s)", value, strings.Join(LevelCodes, ", "))
+			}
+			c.Level = value
+			return nil
+		},
+	},
+	{
+		Key:         "auto_translate",
+		Type:        "bool",
+		Description: "Show the English translation under each story",
+		get:         func(c *Config) interface{} { return c.AutoTranslate },
+		set: func(c *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("auto_translate must be true or false, got %q", value)
+			}
+			c.AutoTranslate = enabled
+			return nil
+		},
+	},
+	{
+		Key:         "transliteration",
+		Type:        "bool",
+		Description: "Show stories and vocabulary in Latin letters too, and accept Latin answers",
+		get:         func(c *Config) interface{} { return c.Transliteration },
+		set: func(c *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("transliteration must be true or false, got %q", value)
+			}
+			c.Transliteration = enabled
+			return nil
+		},
+	},
+	{
+		Key:         "phonetic_input",
+		Type:        "bool",
+		Description: "Convert answers typed in Latin letters into the language's script as you type",
+		get:         func(c *Config) interface{} { return c.PhoneticInput },
+		set: func(c *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("phonetic_input must be true or false, got %q", value)
+			}
+			c.PhoneticInput = enabled
+			return nil
+		},
+	},
+	{
+		Key:         "answer_tolerance",
+		Type:        "int",
+		Description: "Typos per answer reported as almost correct (0 for exact answers)",
+		get:         func(c *Config) interface{} { return c.AnswerTolerance },
+		set: func(c *Config, value string) error {
+			tolerance, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("answer_tolerance must be a whole number, got %q", value)
+			}
+			if tolerance < 0 || tolerance > MaxAnswerTolerance {
+				return fmt.Errorf("answer_tolerance must be between 0 and %d", MaxAnswerTolerance)
+			}
+			c.AnswerTolerance = tolerance
+			return nil
+		},
+	},
+	{
+		Key:         "mastery_mode",
+		Type:        "bool",
+		Description: "Repeat exercises until each is answered correctly twice",
+		get:         func(c *Config) interface{} { return c.MasteryMode },
+		set: func(c *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("mastery_mode must be true or false, got %q", value)
+			}
+			c.MasteryMode = enabled
+			return nil
+		},
+	},
+	{
+		Key:         "timed_mode",
+		Type:        "bool",
+		Description: "Answer exercises against the clock, skipping them when time is up",
+		get:         func(c *Config) interface{} { return c.TimedMode },
+		set: func(c *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("timed_mode must be true or false, got %q", value)
+			}
+			c.TimedMode = enabled
+			return nil
+		},
+	},
+	{
+		Key:         "question_time_limit",
+		Type:        "int",
+		Description: "Seconds per exercise in timed mode (0 for no limit)",
+		get:         func(c *Config) interface{} { return c.QuestionTimeLimit },
+		set: func(c *Config, value string) error {
+			seconds, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("question_time_limit must be a whole number, got %q", value)
+			}
+			if seconds < 0 || seconds > MaxQuestionTimeLimit {
+				return fmt.Errorf("question_time_limit must be between 0 and %d", MaxQuestionTimeLimit)
+			}
+			c.QuestionTimeLimit = seconds
+			return nil
+		},
+	},
+	{
+		Key:         "quiz_time_limit",
+		Type:        "int",
+		Description: "Seconds for all exercises of a story in timed mode (0 for no limit)",
+		get:         func(c *Config) interface{} { return c.QuizTimeLimit },
+		set: func(c *Config, value string) error {
+			seconds, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("quiz_time_limit must be a whole number, got %q", value)
+			}
+			if seconds < 0 || seconds > MaxQuizTimeLimit {
+				return fmt.Errorf("quiz_time_limit must be between 0 and %d", MaxQuizTimeLimit)
+			}
+			c.QuizTimeLimit = seconds
+			return nil
+		},
+	},
+	{
+		Key:         "native_language",
+		Type:        "string",
+		Description: "Language feedback on answers is written in",
+		get:         func(c *Config) interface{} { return c.NativeLanguage },
+		set: func(c *Config, value string) error {
+			if value == "" || len([]rune(value)) > maxNativeLanguageLength {
+				return fmt.Errorf("native_language must be a language name of at most %d characters", maxNativeLanguageLength)
+			}
+			c.NativeLanguage = value
+			return nil
+		},
+	},
+	{
+		Key:         "daily_goal",
+		Type:        "int",
+		Description: "Stories to complete per day",
+		get:         func(c *Config) interface{} { return c.DailyGoal },
+		set: func(c *Config, value string) error {
+			goal, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("daily_goal must be a whole number, got %q", value)
+			}
+			if goal < 1 || goal > MaxDailyGoal {
+				return fmt.Errorf("daily_goal must be between 1 and %d", MaxDailyGoal)
+			}
+			c.DailyGoal = goal
+			return nil
+		},
+	},
+}
+
+// MaxAnswerTolerance keeps fuzzy matching from accepting different words.
+const MaxAnswerTolerance = 3
+
+// Upper bounds for the timed mode limits, in seconds.
+const (
+	MaxQuestionTimeLimit = 600
+	MaxQuizTimeLimit     = 3600
+)
+
+// maxNativeLanguageLength keeps the name usable in prompts.
+const maxNativeLanguageLength = 30
+
+// MaxDailyGoal caps the daily goal at something a learner can finish.
+const MaxDailyGoal = 20
+
+func LookupField(key string) (Field, error) {
+	for _, field := range Fields {
+		if field.Key == key {
+			return field, nil
+		}
+	}
+
+	var keys []string
+	for _, field := range Fields {
+		keys = append(keys, field.Key)
+	}
+	return Field{}, fmt.Errorf("unknown setting %q (available: %s)", key, strings.Join(keys, ", "))
+}
+
+func (f Field) Get(c *Config) interface{} {
+	return f.get(c)
+}
+
+func (f Field) Set(c *Config, value string) error {
+	return f.set(c, strings.TrimSpace(value))
+}
+
+// Validate runs every field's current value back through its setter so a
+// hand-edited config gets the same checks and normalization as
+// `config set`. The config is only changed when every field is valid.
+func (c *Config) Validate() error {
+	check := *c
+	for _, field := range Fields {
+		if err := field.Set(&check, fmt.Sprint(field.Get(c))); err != nil {
+			return err
+		}
+	}
+	*c = check
+	return nil
+}
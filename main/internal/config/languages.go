@@ -0,0 +1,157 @@
+package config
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/translit"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed languages/*.json
+var embeddedLanguages embed.FS
+
+// Languages is the registry of supported languages, keyed by Language.Key.
+// It is seeded from the embedded definitions and extended by LoadLanguages.
+var Languages = map[string]Language{}
+
+func init() {
+	if err := loadLanguages(embeddedLanguages, "languages"); err != nil {
+		panic(fmt.Sprintf("invalid embedded language definitions: %v", err))
+	}
+}
+
+// LoadLanguages merges user-provided definitions from the languages directory
+// into the registry. A user file with the same key overrides the built-in one.
+func (m *Manager) LoadLanguages() error {
+	if _, err := os.Stat(m.languagesDir); os.IsNotExist(err) {
+		return nil
+	}
+	return loadLanguages(os.DirFS(m.languagesDir), ".")
+}
+
+// LanguageKeys returns the registered language keys in menu order.
+func LanguageKeys() []string {
+	var keys []string
+	for key := range Languages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func loadLanguages(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		var lang Language
+		if err := json.Unmarshal(data, &lang); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if lang.Key == "" {
+			lang.Key = strings.TrimSuffix(path.Base(file), ".json")
+		}
+		if err := lang.validate(); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		Languages[lang.Key] = lang
+	}
+	return nil
+}
+
+func (l *Language) validate() error {
+	if l.Display == "" || l.Code == "" {
+		return fmt.Errorf("language %q needs display and code", l.Key)
+	}
+	if l.Name == "" {
+		l.Name = strings.Title(l.Key)
+	}
+	switch l.Direction {
+	case "":
+		l.Direction = "ltr"
+	case "ltr", "rtl":
+	default:
+		return fmt.Errorf("language %q has invalid direction %q", l.Key, l.Direction)
+	}
+	if l.SentenceDelimiters == "" {
+		l.SentenceDelimiters = ".!?"
+	}
+	if l.Transliteration != "" && !translit.Supported(l.Transliteration) {
+		return fmt.Errorf("language %q has unknown transliteration %q", l.Key, l.Transliteration)
+	}
+	return nil
+}
+
+// NormalizeAnswer applies the language's normalization rules so that answers
+// can be compared without tripping over case, punctuation, composed and
+// decomposed characters or spelling variants.
+func (l Language) NormalizeAnswer(answer string) string {
+	rules := l.Normalization
+	answer = norm.NFC.String(strings.TrimSpace(answer))
+
+	answer = replacer(rules.Replacements).Replace(answer)
+	if rules.StripDiacritics {
+		answer = stripDiacritics(answer)
+	}
+	if rules.CaseFold {
+		answer = cases.Fold().String(answer)
+	}
+	if rules.TrimPunctuation {
+		answer = strings.TrimFunc(answer, func(r rune) bool {
+			return unicode.IsPunct(r) || unicode.IsSpace(r)
+		})
+	}
+	return strings.Join(strings.Fields(answer), " ")
+}
+
+// replacer applies the replacements in one pass, preferring the longest
+// match so that overlapping keys give the same result on every run.
+func replacer(replacements map[string]string) *strings.Replacer {
+	keys := make([]string, 0, len(replacements))
+	for from := range replacements {
+		if from != "" {
+			keys = append(keys, from)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	var pairs []string
+	for _, from := range keys {
+		pairs = append(pairs, from, replacements[from])
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// stripDiacritics drops combining marks such as accents or Urdu zer and
+// zabar, keeping the base letters.
+func stripDiacritics(s string) string {
+	var out strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			out.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(out.String())
+}
@@ -14,8 +14,10 @@ const (
 )
 
 type Manager struct {
-	configDir  string
-	configFile string
+	appDir       string
+	configDir    string
+	configFile   string
+	languagesDir string
 }
 
 func NewManager() (*Manager, error) {
@@ -27,26 +29,55 @@ func NewManager() (*Manager, error) {
 	appDir := filepath.Join(homeDir, ".local", "share", "polyglot-stories")
 	configDir := filepath.Join(appDir, "config")
 	configFile := filepath.Join(configDir, "app_config.json")
+	languagesDir := filepath.Join(appDir, "languages")
 
 	return &Manager{
-		configDir:  configDir,
-		configFile: configFile,
+		appDir:       appDir,
+		configDir:    configDir,
+		configFile:   configFile,
+		languagesDir: languagesDir,
 	}, nil
 }
 
+func DefaultConfig() *Config {
+	return &Config{
+		Language:          "russian",
+		Level:             "A1",
+		AutoTranslate:     true,
+		DailyGoal:         1,
+		AnswerTolerance:   1,
+		NativeLanguage:    "English",
+		QuestionTimeLimit: 20,
+	}
+}
+
+// DataDir returns the directory holding all application data.
+func (m *Manager) DataDir() string {
+	return m.appDir
+}
+
+// PluginsDir returns the directory searched for exercise plugins.
+func (m *Manager) PluginsDir() string {
+	return filepath.Join(m.appDir, "plugins")
+}
+
+// DatabaseFile returns the path of the SQLite progress database.
+func (m *Manager) DatabaseFile() string {
+	return filepath.Join(m.appDir, "polyglot.db")
+}
+
+// ConfigFile returns the path of the JSON config file.
+func (m *Manager) ConfigFile() string {
+	return m.configFile
+}
+
 func (m *Manager) Load() (*Config, error) {
 	// Create directories if they don't exist
 	if err := os.MkdirAll(m.configDir, 0755); err != nil {
 		return nil, err
 	}
 
-	// Default config
-	defaultConfig := &Config{
-		Language:      "russian",
-		Level:         "beginner",
-		AutoTranslate: true,
-		DailyGoal:     1,
-	}
+	defaultConfig := DefaultConfig()
 
 	// Check if config file exists
 	if _, err := os.Stat(m.configFile); os.IsNotExist(err) {
@@ -63,11 +94,20 @@ func (m *Manager) Load() (*Config, error) {
 		return nil, err
 	}
 
-	var config Config
+	// Settings missing from older config files keep their defaults
+	config := *defaultConfig
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
 
+	// Migrate configs written before the six-level CEFR scale
+	if code, ok := LegacyLevels[config.Level]; ok {
+		config.Level = code
+		if err := m.Save(&config); err != nil {
+			return nil, err
+		}
+	}
+
 	return &config, nil
 }
 
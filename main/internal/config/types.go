@@ -1,30 +1,103 @@
 package config
 
 type Config struct {
-	Language      string `json:"language"`
-	Level         string `json:"level"`
-	AutoTranslate bool   `json:"auto_translate"`
-	DailyGoal     int    `json:"daily_goal"`
+	Language          string `json:"language"`
+	Level             string `json:"level"`
+	AutoTranslate     bool   `json:"auto_translate"`
+	Transliteration   bool   `json:"transliteration"`
+	PhoneticInput     bool   `json:"phonetic_input"`
+	AnswerTolerance   int    `json:"answer_tolerance"`
+	MasteryMode       bool   `json:"mastery_mode"`
+	TimedMode         bool   `json:"timed_mode"`
+	QuestionTimeLimit int    `json:"question_time_limit"` // seconds, 0 for none
+	QuizTimeLimit     int    `json:"quiz_time_limit"`     // seconds, 0 for none
+	NativeLanguage    string `json:"native_language"`
+	DailyGoal         int    `json:"daily_goal"`
 }
 
 type Language struct {
-	Display string
-	Code    string
+	Key                string             `json:"key"`
+	Name               string             `json:"name"`
+	Display            string             `json:"display"`
+	Code               string             `json:"code"`
+	Script             string             `json:"script"`
+	Direction          string             `json:"direction"`
+	SentenceDelimiters string             `json:"sentence_delimiters"`
+	GrammarFeatures    []string           `json:"grammar_features"`
+	Transliteration    string             `json:"transliteration"`
+	Phonetic           map[string]string  `json:"phonetic"`
+	Normalization      NormalizationRules `json:"normalization"`
+}
+
+type NormalizationRules struct {
+	CaseFold        bool              `json:"case_fold"`
+	StripDiacritics bool              `json:"strip_diacritics"`
+	TrimPunctuation bool              `json:"trim_punctuation"`
+	Replacements    map[string]string `json:"replacements"`
 }
 
 type Level struct {
 	Code        string
+	Name        string
 	Description string
+	Constraints Constraints
 }
 
-var Languages = map[string]Language{
-	"russian": {"🇷🇺 Russian", "ru"},
-	"urdu":    {"🇵🇰 Urdu", "ur"},
-	"english": {"🇺🇸 English", "en"},
+// Constraints steer story generation for a level and are checked against
+// the generated story.
+type Constraints struct {
+	WordCount         int
+	MaxSentenceLength int
+	Tenses            []string
+	FrequencyBand     string
 }
 
+// LevelCodes lists the CEFR levels from easiest to hardest.
+var LevelCodes = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
 var Levels = map[string]Level{
-	"beginner":     {"A1", "Simple vocabulary, basic sentences"},
-	"intermediate": {"A2-B1", "Complex sentences, everyday topics"},
-	"advanced":     {"B2-C1", "Advanced grammar, technical topics"},
+	"A1": {"A1", "Beginner", "Simple vocabulary, basic sentences", Constraints{
+		WordCount:         80,
+		MaxSentenceLength: 8,
+		Tenses:            []string{"present simple"},
+		FrequencyBand:     "the 500 most frequent words",
+	}},
+	"A2": {"A2", "Elementary", "Short everyday stories, simple past and future", Constraints{
+		WordCount:         120,
+		MaxSentenceLength: 12,
+		Tenses:            []string{"present simple", "past simple", "future simple"},
+		FrequencyBand:     "the 1000 most frequent words",
+	}},
+	"B1": {"B1", "Intermediate", "Connected narratives on familiar topics", Constraints{
+		WordCount:         180,
+		MaxSentenceLength: 16,
+		Tenses:            []string{"present", "past", "future", "present perfect", "continuous forms"},
+		FrequencyBand:     "the 2000 most frequent words",
+	}},
+	"B2": {"B2", "Upper Intermediate", "Complex sentences, abstract and technical topics", Constraints{
+		WordCount:         250,
+		MaxSentenceLength: 22,
+		Tenses:            []string{"all indicative tenses", "conditionals", "passive voice"},
+		FrequencyBand:     "the 4000 most frequent words",
+	}},
+	"C1": {"C1", "Advanced", "Advanced grammar, nuanced and idiomatic language", Constraints{
+		WordCount:         350,
+		MaxSentenceLength: 28,
+		Tenses:            []string{"all tenses and moods", "participle and gerund constructions"},
+		FrequencyBand:     "the 8000 most frequent words plus domain terms",
+	}},
+	"C2": {"C2", "Proficient", "Native-like texts, literary and specialist style", Constraints{
+		WordCount:         450,
+		MaxSentenceLength: 35,
+		Tenses:            []string{"any tense, mood or register"},
+		FrequencyBand:     "unrestricted vocabulary including rare and literary words",
+	}},
+}
+
+// LegacyLevels maps the three-bucket level names of earlier versions and
+// the bash prototype onto CEFR codes.
+var LegacyLevels = map[string]string{
+	"beginner":     "A1",
+	"intermediate": "B1",
+	"advanced":     "C1",
 }
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// timeLayout matches SQLite's CURRENT_TIMESTAMP so stored and defaulted
+// timestamps compare and sort alike.
+const timeLayout = "2006-01-02 15:04:05"
+
+// DB is the progress database shared by the terminal UI and future
+// front ends.
+type DB struct {
+	conn *sql.DB
+}
+
+func Open(path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	conn, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; one connection avoids lock errors
+	conn.SetMaxOpenConns(1)
+
+	db := &DB{conn: conn}
+	if err := db.migrate(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	return db, nil
+}
+
+// OpenLocal opens the database at path and returns it with the local
+// learner's user ID.
+func OpenLocal(path string) (*DB, int64, error) {
+	store, err := Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	userID, err := store.LocalUser()
+	if err != nil {
+		store.Close()
+		return nil, 0, err
+	}
+	return store, userID, nil
+}
+
+func (d *DB) Close() error {
+	return d.conn.Close()
+}
+
+// migrate applies every numbered migration newer than the recorded schema
+// version, each in its own transaction.
+func (d *DB) migrate() error {
+	if _, err := d.conn.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := d.conn.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&current); err != nil {
+		return err
+	}
+
+	files, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		name := strings.TrimPrefix(file, "migrations/")
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("migration %s has no version number", name)
+		}
+		if version <= current {
+			continue
+		}
+
+		script, err := migrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := d.applyMigration(version, string(script)); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// applyMigration runs one migration script in a transaction. Foreign keys
+// are switched off meanwhile so scripts can rebuild tables, the documented
+// way to change column constraints in SQLite, and checked before commit.
+func (d *DB) applyMigration(version int, script string) error {
+	if _, err := d.conn.Exec(`PRAGMA foreign_keys = OFF`); err != nil {
+		return err
+	}
+	defer d.conn.Exec(`PRAGMA foreign_keys = ON`)
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(script); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`PRAGMA foreign_key_check`)
+	if err != nil {
+		return err
+	}
+	violation := rows.Next()
+	rows.Close()
+	if violation {
+		return fmt.Errorf("migration leaves foreign key violations")
+	}
+
+	if _, err := tx.Exec(`INSERT INTO schema_version (version) VALUES (?)`, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
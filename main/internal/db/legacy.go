@@ -0,0 +1,363 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+)
+
+// ImportSummary counts what ImportLegacy migrated.
+type ImportSummary struct {
+	Stories           int
+	DuplicateStories  int
+	NewVocabulary     int
+	MergedVocabulary  int
+	Exercises         int
+	AnsweredExercises int
+	Sessions          int
+	// UnknownTimestamps were missing or unreadable and are left empty.
+	UnknownTimestamps int
+}
+
+// legacyTimeLayouts covers the timestamp formats the bash prototype wrote.
+var legacyTimeLayouts = []string{
+	timeLayout,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ImportLegacy copies stories, vocabulary, exercise results, sessions and
+// progress totals from a database created by the bash prototype into this
+// database for userID. Stories already present are skipped along with their vocabulary
+// and exercises, so importing the same file twice changes nothing.
+func (d *DB) ImportLegacy(path string, userID int64) (*ImportSummary, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	// As a URI, so ?, # and % in the path are escaped
+	uri := url.URL{Scheme: "file", Path: filepath.ToSlash(abs), RawQuery: "mode=ro"}
+	legacy, err := sql.Open("sqlite", uri.String())
+	if err != nil {
+		return nil, err
+	}
+	defer legacy.Close()
+
+	if err := legacy.Ping(); err != nil {
+		return nil, fmt.Errorf("cannot open legacy database: %w", err)
+	}
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	summary := &ImportSummary{}
+	storyIDs, err := importLegacyStories(legacy, tx, userID, summary)
+	if err != nil {
+		return nil, fmt.Errorf("stories: %w", err)
+	}
+	if err := importLegacyVocabulary(legacy, tx, storyIDs, summary); err != nil {
+		return nil, fmt.Errorf("vocabulary: %w", err)
+	}
+	if err := importLegacyExercises(legacy, tx, userID, storyIDs, summary); err != nil {
+		return nil, fmt.Errorf("exercises: %w", err)
+	}
+	if err := importLegacySessions(legacy, tx, userID, summary); err != nil {
+		return nil, fmt.Errorf("sessions: %w", err)
+	}
+	if err := importLegacyProgress(legacy, tx, userID); err != nil {
+		return nil, fmt.Errorf("progress: %w", err)
+	}
+
+	return summary, tx.Commit()
+}
+
+// importLegacyStories returns a map from legacy story ids to the ids of
+// newly inserted stories. Duplicates are left out of the map.
+func importLegacyStories(legacy *sql.DB, tx *sql.Tx, userID int64, summary *ImportSummary) (map[int64]int64, error) {
+	rows, err := legacy.Query(`SELECT story_id, session_id, language, level, topic, COALESCE(title, ''),
+		story_text, translation, COALESCE(word_count, 0), COALESCE(reading_time_minutes, 1),
+		COALESCE(ai_model_used, ''), created_at
+		FROM stories ORDER BY story_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	storyIDs := map[int64]int64{}
+	for rows.Next() {
+		var legacyID int64
+		var sessionID, language, level, topic, title, storyText, translation, model string
+		var wordCount, readingMinutes int
+		var createdAt interface{}
+		if err := rows.Scan(&legacyID, &sessionID, &language, &level, &topic, &title,
+			&storyText, &translation, &wordCount, &readingMinutes, &model, &createdAt); err != nil {
+			return nil, err
+		}
+
+		var existing int64
+		err := tx.QueryRow(`SELECT story_id FROM stories WHERE user_id = ? AND language = ? AND story_text = ?`,
+			userID, language, storyText).Scan(&existing)
+		if err == nil {
+			summary.DuplicateStories++
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		result, err := tx.Exec(`INSERT INTO stories
+			(user_id, session_id, language, level, topic, title, story_text, translation, word_count, reading_time_minutes, ai_model_used, created_at)
+			VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?)`,
+			userID, sessionID, language, legacyLevel(level), topic, title, storyText, translation,
+			wordCount, readingMinutes, model, summary.timestamp(createdAt))
+		if err != nil {
+			return nil, err
+		}
+		storyID, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		storyIDs[legacyID] = storyID
+		summary.Stories++
+	}
+
+	if summary.Stories > 0 {
+		if _, err := tx.Exec(`UPDATE users SET total_stories = total_stories + ? WHERE user_id = ?`,
+			summary.Stories, userID); err != nil {
+			return nil, err
+		}
+	}
+	return storyIDs, rows.Err()
+}
+
+func importLegacyVocabulary(legacy *sql.DB, tx *sql.Tx, storyIDs map[int64]int64, summary *ImportSummary) error {
+	rows, err := legacy.Query(`SELECT story_id, word, translation, COALESCE(example_sentence, ''), language,
+		COALESCE(difficulty_level, 'beginner'), COALESCE(times_encountered, 1), last_encountered
+		FROM vocabulary ORDER BY vocab_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var legacyStoryID int64
+		var word, translation, example, language, level string
+		var timesEncountered int
+		var lastEncountered interface{}
+		if err := rows.Scan(&legacyStoryID, &word, &translation, &example, &language,
+			&level, &timesEncountered, &lastEncountered); err != nil {
+			return err
+		}
+
+		storyID, ok := storyIDs[legacyStoryID]
+		if !ok || word == "" {
+			continue
+		}
+
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM vocabulary WHERE language = ? AND word = ?)`,
+			language, word).Scan(&exists); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`INSERT INTO vocabulary
+			(story_id, word, translation, example_sentence, language, difficulty_level, times_encountered, last_encountered)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(language, word) DO UPDATE SET
+				times_encountered = times_encountered + excluded.times_encountered,
+				last_encountered = COALESCE(MAX(last_encountered, excluded.last_encountered), last_encountered)`,
+			storyID, word, translation, example, language, legacyLevel(level),
+			timesEncountered, summary.timestamp(lastEncountered)); err != nil {
+			return err
+		}
+		if err := addReviewCards(tx, language, word, time.Now()); err != nil {
+			return err
+		}
+
+		if exists {
+			summary.MergedVocabulary++
+		} else {
+			summary.NewVocabulary++
+		}
+	}
+	return rows.Err()
+}
+
+func importLegacyExercises(legacy *sql.DB, tx *sql.Tx, userID int64, storyIDs map[int64]int64, summary *ImportSummary) error {
+	rows, err := legacy.Query(`SELECT e.story_id, e.exercise_type, e.question, e.correct_answer,
+		COALESCE(e.options, '[]'), e.user_answer, e.is_correct, e.completed_at, s.language, s.level
+		FROM exercises e JOIN stories s ON e.story_id = s.story_id
+		ORDER BY e.exercise_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var legacyStoryID int64
+		var exerciseType, question, answer, options, language, level string
+		var userAnswer sql.NullString
+		var isCorrect sql.NullBool
+		var completedAt interface{}
+		if err := rows.Scan(&legacyStoryID, &exerciseType, &question, &answer, &options,
+			&userAnswer, &isCorrect, &completedAt, &language, &level); err != nil {
+			return err
+		}
+
+		storyID, ok := storyIDs[legacyStoryID]
+		if !ok {
+			continue
+		}
+
+		completed := sql.NullString{}
+		if isCorrect.Valid {
+			completed = summary.timestamp(completedAt)
+		}
+		if _, err := tx.Exec(`INSERT INTO exercises
+			(story_id, exercise_type, question, correct_answer, options, user_answer, is_correct, completed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			storyID, exerciseType, question, answer, options, userAnswer, isCorrect, completed); err != nil {
+			return err
+		}
+		summary.Exercises++
+
+		if !isCorrect.Valid {
+			continue
+		}
+		summary.AnsweredExercises++
+
+		if _, err := tx.Exec(`UPDATE users SET total_exercises = total_exercises + 1 WHERE user_id = ?`, userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO user_progress (user_id, session_id, language, level, exercises_completed, correct_answers, updated_at)
+			SELECT user_id, session_id, ?, ?, 1, ?, ? FROM users WHERE user_id = ?
+			ON CONFLICT(user_id, language, level) DO UPDATE SET
+				exercises_completed = exercises_completed + 1,
+				correct_answers = correct_answers + excluded.correct_answers`,
+			language, legacyLevel(level), boolToInt(isCorrect.Bool), completed, userID); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func importLegacySessions(legacy *sql.DB, tx *sql.Tx, userID int64, summary *ImportSummary) error {
+	rows, err := legacy.Query(`SELECT session_id, language, level, start_time, end_time,
+		COALESCE(duration_minutes, 0), COALESCE(stories_generated, 0), COALESCE(exercises_completed, 0),
+		COALESCE(accuracy_rate, 0)
+		FROM learning_sessions`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sessionID, language, level string
+		var startTime, endTime interface{}
+		var duration, storiesGenerated, exercisesCompleted int
+		var accuracy float64
+		if err := rows.Scan(&sessionID, &language, &level, &startTime, &endTime,
+			&duration, &storiesGenerated, &exercisesCompleted, &accuracy); err != nil {
+			return err
+		}
+
+		end := sql.NullString{}
+		if endTime != nil {
+			end = summary.timestamp(endTime)
+		}
+		result, err := tx.Exec(`INSERT OR IGNORE INTO learning_sessions
+			(session_id, user_id, language, level, start_time, end_time, duration_minutes, stories_generated, exercises_completed, accuracy_rate)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			sessionID, userID, language, legacyLevel(level), summary.timestamp(startTime), end,
+			duration, storiesGenerated, exercisesCompleted, accuracy)
+		if err != nil {
+			return err
+		}
+		if inserted, _ := result.RowsAffected(); inserted > 0 {
+			summary.Sessions++
+		}
+	}
+	return rows.Err()
+}
+
+// importLegacyProgress copies the story counts, study time and last study
+// date kept per language and level. Counts are merged by taking the larger
+// value, so importing again doesn't add them twice. Streaks are left to the
+// progress tracker; the bash version never recorded them.
+func importLegacyProgress(legacy *sql.DB, tx *sql.Tx, userID int64) error {
+	rows, err := legacy.Query(`SELECT language, level, COALESCE(stories_completed, 0),
+		COALESCE(total_time_minutes, 0), last_study_date
+		FROM user_progress`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var language, level string
+		var stories, minutes int
+		var lastStudied interface{}
+		if err := rows.Scan(&language, &level, &stories, &minutes, &lastStudied); err != nil {
+			return err
+		}
+
+		studyDate := sql.NullString{}
+		if t, ok := legacyTime(lastStudied); ok {
+			studyDate = sql.NullString{String: t.Format("2006-01-02"), Valid: true}
+		}
+		if _, err := tx.Exec(`INSERT INTO user_progress
+			(user_id, session_id, language, level, stories_completed, total_time_minutes, last_study_date)
+			SELECT user_id, session_id, ?, ?, ?, ?, ? FROM users WHERE user_id = ?
+			ON CONFLICT(user_id, language, level) DO UPDATE SET
+				stories_completed = MAX(stories_completed, excluded.stories_completed),
+				total_time_minutes = MAX(total_time_minutes, excluded.total_time_minutes),
+				last_study_date = COALESCE(MAX(last_study_date, excluded.last_study_date), last_study_date, excluded.last_study_date)`,
+			language, legacyLevel(level), stories, minutes, studyDate, userID); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func legacyLevel(level string) string {
+	if code, ok := config.LegacyLevels[level]; ok {
+		return code
+	}
+	return level
+}
+
+// timestamp converts a scanned legacy timestamp into this database's
+// format. Missing or unreadable values become NULL and are counted.
+func (s *ImportSummary) timestamp(value interface{}) sql.NullString {
+	t, ok := legacyTime(value)
+	if !ok {
+		s.UnknownTimestamps++
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTime(t), Valid: true}
+}
+
+func legacyTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range legacyTimeLayouts {
+			if t, err := time.ParseInLocation(layout, v, time.UTC); err == nil {
+				return t, true
+			}
+		}
+	case []byte:
+		return legacyTime(string(v))
+	}
+	return time.Time{}, false
+}
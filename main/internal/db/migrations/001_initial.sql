@@ -0,0 +1,98 @@
+-- Schema ported from the bash prototype (polygot-test.sh).
+-- Differences: vocabulary is unique per language and word, with
+-- times_encountered counting repeat sightings, and the exercise type is
+-- not restricted so new exercise types can be stored.
+
+CREATE TABLE IF NOT EXISTS users (
+    user_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT UNIQUE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_active DATETIME DEFAULT CURRENT_TIMESTAMP,
+    total_stories INTEGER DEFAULT 0,
+    total_exercises INTEGER DEFAULT 0,
+    preferred_language TEXT DEFAULT 'russian',
+    preferred_level TEXT DEFAULT 'A1'
+);
+
+CREATE TABLE IF NOT EXISTS stories (
+    story_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    session_id TEXT NOT NULL,
+    language TEXT NOT NULL,
+    level TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    title TEXT,
+    story_text TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    word_count INTEGER DEFAULT 0,
+    reading_time_minutes INTEGER DEFAULT 1,
+    ai_model_used TEXT DEFAULT 'gpt-oss:120b-cloud',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+CREATE TABLE IF NOT EXISTS vocabulary (
+    vocab_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    story_id INTEGER NOT NULL,
+    word TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    example_sentence TEXT,
+    language TEXT NOT NULL,
+    difficulty_level TEXT DEFAULT 'A1',
+    times_encountered INTEGER DEFAULT 1,
+    last_encountered DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (story_id) REFERENCES stories(story_id)
+);
+
+CREATE TABLE IF NOT EXISTS exercises (
+    exercise_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    story_id INTEGER NOT NULL,
+    exercise_type TEXT NOT NULL,
+    question TEXT NOT NULL,
+    correct_answer TEXT NOT NULL,
+    options JSON,
+    user_answer TEXT,
+    is_correct BOOLEAN,
+    completed_at DATETIME,
+    FOREIGN KEY (story_id) REFERENCES stories(story_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_progress (
+    progress_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    session_id TEXT NOT NULL,
+    language TEXT NOT NULL,
+    level TEXT NOT NULL,
+    stories_completed INTEGER DEFAULT 0,
+    exercises_completed INTEGER DEFAULT 0,
+    correct_answers INTEGER DEFAULT 0,
+    total_time_minutes INTEGER DEFAULT 0,
+    streak_days INTEGER DEFAULT 0,
+    last_study_date DATE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id),
+    FOREIGN KEY (session_id) REFERENCES users(session_id),
+    UNIQUE(user_id, language, level)
+);
+
+CREATE TABLE IF NOT EXISTS learning_sessions (
+    session_id TEXT PRIMARY KEY,
+    user_id INTEGER,
+    language TEXT NOT NULL,
+    level TEXT NOT NULL,
+    start_time DATETIME DEFAULT CURRENT_TIMESTAMP,
+    end_time DATETIME,
+    duration_minutes INTEGER DEFAULT 0,
+    stories_generated INTEGER DEFAULT 0,
+    exercises_completed INTEGER DEFAULT 0,
+    accuracy_rate REAL DEFAULT 0.0,
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stories_user_language ON stories(user_id, language);
+CREATE INDEX IF NOT EXISTS idx_stories_created_at ON stories(created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vocabulary_language_word ON vocabulary(language, word);
+CREATE INDEX IF NOT EXISTS idx_exercises_story_type ON exercises(story_id, exercise_type);
+CREATE INDEX IF NOT EXISTS idx_user_progress_language_level ON user_progress(language, level);
+CREATE INDEX IF NOT EXISTS idx_learning_sessions_time ON learning_sessions(start_time);
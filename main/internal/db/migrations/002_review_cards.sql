@@ -0,0 +1,25 @@
+-- Spaced-repetition deck: one card per vocabulary word and direction.
+-- recognition shows the word and asks for its translation,
+-- recall shows the translation and asks for the word.
+
+CREATE TABLE IF NOT EXISTS review_cards (
+    card_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    vocab_id INTEGER NOT NULL,
+    direction TEXT NOT NULL CHECK (direction IN ('recognition', 'recall')),
+    ease_factor REAL DEFAULT 2.5,
+    interval_days INTEGER DEFAULT 0,
+    repetitions INTEGER DEFAULT 0,
+    due_date DATE NOT NULL,
+    last_reviewed DATETIME,
+    FOREIGN KEY (vocab_id) REFERENCES vocabulary(vocab_id),
+    UNIQUE(vocab_id, direction)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_cards_due ON review_cards(due_date);
+
+-- Words collected before the deck existed are due right away
+INSERT OR IGNORE INTO review_cards (vocab_id, direction, due_date)
+SELECT vocab_id, 'recognition', date('now', 'localtime') FROM vocabulary;
+
+INSERT OR IGNORE INTO review_cards (vocab_id, direction, due_date)
+SELECT vocab_id, 'recall', date('now', 'localtime') FROM vocabulary;
@@ -0,0 +1,36 @@
+-- Vocabulary notebook: words can be added by hand without a source story,
+-- marked as known, and tagged. Rebuilds vocabulary to make story_id optional.
+
+CREATE TABLE vocabulary_new (
+    vocab_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    story_id INTEGER,
+    word TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    example_sentence TEXT,
+    language TEXT NOT NULL,
+    difficulty_level TEXT DEFAULT 'A1',
+    times_encountered INTEGER DEFAULT 1,
+    last_encountered DATETIME DEFAULT CURRENT_TIMESTAMP,
+    known BOOLEAN DEFAULT 0,
+    user_added BOOLEAN DEFAULT 0,
+    FOREIGN KEY (story_id) REFERENCES stories(story_id)
+);
+
+INSERT INTO vocabulary_new
+    (vocab_id, story_id, word, translation, example_sentence, language, difficulty_level, times_encountered, last_encountered)
+SELECT vocab_id, story_id, word, translation, example_sentence, language, difficulty_level, times_encountered, last_encountered
+FROM vocabulary;
+
+DROP TABLE vocabulary;
+ALTER TABLE vocabulary_new RENAME TO vocabulary;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vocabulary_language_word ON vocabulary(language, word);
+
+CREATE TABLE IF NOT EXISTS vocabulary_tags (
+    vocab_id INTEGER NOT NULL,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (vocab_id, tag),
+    FOREIGN KEY (vocab_id) REFERENCES vocabulary(vocab_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vocabulary_tags_tag ON vocabulary_tags(tag);
@@ -0,0 +1,3 @@
+-- Translations of vocabulary example sentences.
+
+ALTER TABLE vocabulary ADD COLUMN example_translation TEXT;
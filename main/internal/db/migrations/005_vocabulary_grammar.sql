@@ -0,0 +1,9 @@
+-- Grammar metadata for vocabulary. Story words are deduplicated by lemma,
+-- falling back to the word itself when no lemma is known.
+
+ALTER TABLE vocabulary ADD COLUMN lemma TEXT;
+ALTER TABLE vocabulary ADD COLUMN part_of_speech TEXT;
+ALTER TABLE vocabulary ADD COLUMN features TEXT;
+ALTER TABLE vocabulary ADD COLUMN pronunciation TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_vocabulary_language_lemma ON vocabulary(language, COALESCE(NULLIF(lemma, ''), word));
@@ -0,0 +1,4 @@
+-- Hints used for an answer; each costs part of the exercise's point in the
+-- session accuracy.
+
+ALTER TABLE exercises ADD COLUMN hints INTEGER DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Study streaks are kept by the progress tracker (progress.json) alone.
+
+ALTER TABLE user_progress DROP COLUMN streak_days;
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"time"
+)
+
+// Stats summarizes a learner's progress across all languages and levels.
+type Stats struct {
+	TotalStories   int
+	TotalExercises int
+	CorrectAnswers int
+}
+
+func (s Stats) Accuracy() float64 {
+	if s.TotalExercises == 0 {
+		return 0
+	}
+	return float64(s.CorrectAnswers) * 100 / float64(s.TotalExercises)
+}
+
+func (d *DB) StartSession(userID int64, sessionID, language, level string, start time.Time) error {
+	_, err := d.conn.Exec(`INSERT OR IGNORE INTO learning_sessions (session_id, user_id, language, level, start_time)
+		VALUES (?, ?, ?, ?, ?)`, sessionID, userID, language, level, formatTime(start))
+	return err
+}
+
+// RecordAnswer stores the learner's answer to an exercise, with the number
+// of hints used, and updates the per-language progress counters.
+func (d *DB) RecordAnswer(exerciseID int64, answer string, correct bool, hints int, at time.Time) error {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE exercises SET user_answer = ?, is_correct = ?, hints = ?, completed_at = ? WHERE exercise_id = ?`,
+		answer, correct, hints, formatTime(at), exerciseID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET total_exercises = total_exercises + 1, last_active = ?
+		WHERE user_id = (SELECT s.user_id FROM exercises e JOIN stories s ON e.story_id = s.story_id WHERE e.exercise_id = ?)`,
+		formatTime(at), exerciseID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT INTO user_progress (user_id, session_id, language, level, exercises_completed, correct_answers, updated_at)
+		SELECT u.user_id, u.session_id, s.language, s.level, 1, ?, ?
+		FROM exercises e
+		JOIN stories s ON e.story_id = s.story_id
+		JOIN users u ON s.user_id = u.user_id
+		WHERE e.exercise_id = ?
+		ON CONFLICT(user_id, language, level) DO UPDATE SET
+			exercises_completed = exercises_completed + 1,
+			correct_answers = correct_answers + excluded.correct_answers,
+			updated_at = excluded.updated_at`,
+		boolToInt(correct), formatTime(at), exerciseID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// EndSession closes a learning session, storing its duration and accuracy.
+// points is the number of correct answers less the cost of hints. Study
+// streaks are kept by the progress tracker, not here.
+func (d *DB) EndSession(sessionID string, end time.Time, answered int, points float64) error {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var userID int64
+	var language, level string
+	var start time.Time
+	if err := tx.QueryRow(`SELECT user_id, language, level, start_time FROM learning_sessions WHERE session_id = ?`,
+		sessionID).Scan(&userID, &language, &level, &start); err != nil {
+		return err
+	}
+
+	minutes := int(end.Sub(start).Minutes())
+	accuracy := 0.0
+	if answered > 0 {
+		accuracy = points * 100 / float64(answered)
+	}
+
+	if _, err := tx.Exec(`UPDATE learning_sessions
+		SET end_time = ?, duration_minutes = ?, exercises_completed = ?, accuracy_rate = ?
+		WHERE session_id = ?`, formatTime(end), minutes, answered, accuracy, sessionID); err != nil {
+		return err
+	}
+
+	today := end.Local().Format("2006-01-02")
+	if _, err := tx.Exec(`INSERT INTO user_progress
+		(user_id, session_id, language, level, stories_completed, total_time_minutes, last_study_date, updated_at)
+		SELECT user_id, session_id, ?, ?, 1, ?, ?, ? FROM users WHERE user_id = ?
+		ON CONFLICT(user_id, language, level) DO UPDATE SET
+			stories_completed = stories_completed + 1,
+			total_time_minutes = total_time_minutes + excluded.total_time_minutes,
+			last_study_date = excluded.last_study_date,
+			updated_at = excluded.updated_at`,
+		language, level, minutes, today, formatTime(end), userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *DB) Stats(userID int64) (*Stats, error) {
+	var stats Stats
+	err := d.conn.QueryRow(`SELECT u.total_stories, u.total_exercises,
+			COALESCE(SUM(up.correct_answers), 0)
+		FROM users u
+		LEFT JOIN user_progress up ON u.user_id = up.user_id
+		WHERE u.user_id = ?
+		GROUP BY u.user_id`, userID).Scan(&stats.TotalStories, &stats.TotalExercises, &stats.CorrectAnswers)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/srs"
+)
+
+const dateLayout = "2006-01-02"
+
+// Review directions.
+const (
+	Recognition = "recognition"
+	Recall      = "recall"
+)
+
+// ReviewCard is a vocabulary flashcard with its scheduling state.
+type ReviewCard struct {
+	ID          int64
+	VocabID     int64
+	Language    string
+	Word        string
+	Translation string
+	Example     string
+	Direction   string
+	srs.Card
+}
+
+// addReviewCards puts a vocabulary word into the deck in both directions.
+// Words that already have cards keep their schedule.
+func addReviewCards(tx *sql.Tx, language, word string, now time.Time) error {
+	for _, direction := range []string{Recognition, Recall} {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO review_cards (vocab_id, direction, due_date)
+			SELECT vocab_id, ?, ? FROM vocabulary WHERE language = ? AND word = ?`,
+			direction, srs.Day(now).Format(dateLayout), language, word); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DueCards returns up to limit cards of a language due on the day of now,
+// most overdue first.
+func (d *DB) DueCards(language string, now time.Time, limit int) ([]ReviewCard, error) {
+	rows, err := d.conn.Query(`SELECT c.card_id, c.vocab_id, v.language, v.word, v.translation,
+			COALESCE(v.example_sentence, ''), c.direction, c.ease_factor, c.interval_days, c.repetitions, c.due_date
+		FROM review_cards c
+		JOIN vocabulary v ON c.vocab_id = v.vocab_id
+		WHERE v.language = ? AND v.known = 0 AND c.due_date <= ?
+		ORDER BY c.due_date, c.card_id
+		LIMIT ?`, language, srs.Day(now).Format(dateLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []ReviewCard
+	for rows.Next() {
+		var card ReviewCard
+		// The driver reads DATE columns as midnight UTC
+		var due time.Time
+		if err := rows.Scan(&card.ID, &card.VocabID, &card.Language, &card.Word, &card.Translation,
+			&card.Example, &card.Direction, &card.EaseFactor, &card.Interval, &card.Repetitions, &due); err != nil {
+			return nil, err
+		}
+		card.Due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.Local)
+		cards = append(cards, card)
+	}
+	return cards, rows.Err()
+}
+
+// CountDue returns how many cards of a language are due on the day of now.
+func (d *DB) CountDue(language string, now time.Time) (int, error) {
+	var count int
+	err := d.conn.QueryRow(`SELECT COUNT(*) FROM review_cards c
+		JOIN vocabulary v ON c.vocab_id = v.vocab_id
+		WHERE v.language = ? AND v.known = 0 AND c.due_date <= ?`,
+		language, srs.Day(now).Format(dateLayout)).Scan(&count)
+	return count, err
+}
+
+// SaveReview stores the card's new schedule after a review.
+func (d *DB) SaveReview(card ReviewCard, reviewedAt time.Time) error {
+	_, err := d.conn.Exec(`UPDATE review_cards
+		SET ease_factor = ?, interval_days = ?, repetitions = ?, due_date = ?, last_reviewed = ?
+		WHERE card_id = ?`,
+		card.EaseFactor, card.Interval, card.Repetitions, card.Due.Format(dateLayout), formatTime(reviewedAt), card.ID)
+	return err
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// StoryMeta describes where a story came from.
+type StoryMeta struct {
+	SessionID string
+	Language  string
+	Level     string
+	Topic     string
+	Model     string
+	CreatedAt time.Time
+}
+
+// LocalUser returns the id of the learner using this installation,
+// creating the user on first use.
+func (d *DB) LocalUser() (int64, error) {
+	var userID int64
+	err := d.conn.QueryRow(`SELECT user_id FROM users ORDER BY user_id LIMIT 1`).Scan(&userID)
+	if err == nil {
+		return userID, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	suffix := make([]byte, 8)
+	rand.Read(suffix)
+	result, err := d.conn.Exec(`INSERT INTO users (session_id) VALUES (?)`,
+		fmt.Sprintf("user_%d_%s", time.Now().Unix(), hex.EncodeToString(suffix)))
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// SaveStory stores a story with its vocabulary and exercises. A story that
+// is already stored for the same language and text is reused, so practicing
+// it again only adds fresh exercise rows. The returned exercise ids are in
+// the order of story.Exercises.
+func (d *DB) SaveStory(userID int64, meta StoryMeta, story *ai.StoryResponse) (int64, []int64, error) {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	storyID, isNew, err := insertStory(tx, userID, meta, story)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if isNew {
+		for _, vocab := range story.Vocabulary {
+			if err := upsertVocabulary(tx, storyID, meta, vocab); err != nil {
+				return 0, nil, err
+			}
+		}
+		if _, err := tx.Exec(`UPDATE users SET total_stories = total_stories + 1, last_active = ? WHERE user_id = ?`,
+			formatTime(meta.CreatedAt), userID); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	var exerciseIDs []int64
+	for _, exercise := range story.Exercises {
+		options, err := json.Marshal(exercise.Options)
+		if err != nil {
+			return 0, nil, err
+		}
+		result, err := tx.Exec(`INSERT INTO exercises (story_id, exercise_type, question, correct_answer, options)
+			VALUES (?, ?, ?, ?, ?)`, storyID, exercise.Type, exercise.Question, exercise.Answer, string(options))
+		if err != nil {
+			return 0, nil, err
+		}
+		exerciseID, err := result.LastInsertId()
+		if err != nil {
+			return 0, nil, err
+		}
+		exerciseIDs = append(exerciseIDs, exerciseID)
+	}
+
+	if _, err := tx.Exec(`UPDATE learning_sessions SET stories_generated = stories_generated + 1 WHERE session_id = ?`,
+		meta.SessionID); err != nil {
+		return 0, nil, err
+	}
+
+	return storyID, exerciseIDs, tx.Commit()
+}
+
+func insertStory(tx *sql.Tx, userID int64, meta StoryMeta, story *ai.StoryResponse) (int64, bool, error) {
+	var storyID int64
+	err := tx.QueryRow(`SELECT story_id FROM stories WHERE user_id = ? AND language = ? AND story_text = ?`,
+		userID, meta.Language, story.StoryText).Scan(&storyID)
+	if err == nil {
+		return storyID, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, false, err
+	}
+
+	wordCount := len(text.Words(story.StoryText))
+	result, err := tx.Exec(`INSERT INTO stories
+		(user_id, session_id, language, level, topic, story_text, translation, word_count, reading_time_minutes, ai_model_used, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		userID, meta.SessionID, meta.Language, meta.Level, meta.Topic, story.StoryText, story.Translation,
+		wordCount, readingMinutes(wordCount), meta.Model, formatTime(meta.CreatedAt))
+	if err != nil {
+		return 0, false, err
+	}
+
+	storyID, err = result.LastInsertId()
+	return storyID, true, err
+}
+
+func upsertVocabulary(tx *sql.Tx, storyID int64, meta StoryMeta, vocab ai.Vocabulary) error {
+	if vocab.Word == "" || vocab.Translation == "" {
+		return nil
+	}
+	features, err := encodeFeatures(vocab.Features)
+	if err != nil {
+		return err
+	}
+
+	// Inflected forms of a word already in the notebook count as encounters
+	// of that entry, filling in grammar details it was saved without
+	key := vocab.Lemma
+	if key == "" {
+		key = vocab.Word
+	}
+	word := vocab.Word
+	err = tx.QueryRow(`SELECT word FROM vocabulary
+		WHERE language = ? AND (word = ? OR COALESCE(NULLIF(lemma, ''), word) = ?)
+		ORDER BY vocab_id LIMIT 1`, meta.Language, vocab.Word, key).Scan(&word)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(`INSERT INTO vocabulary
+			(story_id, word, translation, example_sentence, example_translation, language, difficulty_level, last_encountered,
+			lemma, part_of_speech, features, pronunciation)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, NULLIF(?, ''))`,
+			storyID, vocab.Word, vocab.Translation, vocab.Example, vocab.ExampleTranslation, meta.Language, meta.Level, formatTime(meta.CreatedAt),
+			vocab.Lemma, vocab.PartOfSpeech, features, vocab.Pronunciation)
+	case err == nil:
+		_, err = tx.Exec(`UPDATE vocabulary SET
+				times_encountered = times_encountered + 1,
+				last_encountered = ?,
+				lemma = COALESCE(lemma, NULLIF(?, '')),
+				part_of_speech = COALESCE(part_of_speech, NULLIF(?, '')),
+				features = COALESCE(features, ?),
+				pronunciation = COALESCE(pronunciation, NULLIF(?, ''))
+			WHERE language = ? AND word = ?`,
+			formatTime(meta.CreatedAt), vocab.Lemma, vocab.PartOfSpeech, features, vocab.Pronunciation, meta.Language, word)
+	}
+	if err != nil {
+		return err
+	}
+	return addReviewCards(tx, meta.Language, word, meta.CreatedAt)
+}
+
+// encodeFeatures stores grammatical features as a JSON object, or NULL when
+// there are none.
+func encodeFeatures(features map[string]string) (interface{}, error) {
+	if len(features) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(features)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// readingMinutes estimates reading time for a learner at ~100 words a minute.
+func readingMinutes(words int) int {
+	if minutes := (words + 99) / 100; minutes > 1 {
+		return minutes
+	}
+	return 1
+}
@@ -0,0 +1,324 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VocabEntry is a word in the vocabulary notebook.
+type VocabEntry struct {
+	ID                 int64             `json:"id"`
+	Language           string            `json:"language"`
+	Word               string            `json:"word"`
+	Translation        string            `json:"translation"`
+	Example            string            `json:"example,omitempty"`
+	ExampleTranslation string            `json:"example_translation,omitempty"`
+	Lemma              string            `json:"lemma,omitempty"`
+	PartOfSpeech       string            `json:"part_of_speech,omitempty"`
+	Features           map[string]string `json:"features,omitempty"`
+	Pronunciation      string            `json:"pronunciation,omitempty"`
+	Level              string            `json:"level"`
+	StoryID            int64             `json:"story_id,omitempty"`
+	StoryTopic         string            `json:"story_topic,omitempty"`
+	TimesEncountered   int               `json:"times_encountered"`
+	LastEncountered    time.Time         `json:"last_encountered"`
+	Known              bool              `json:"known"`
+	UserAdded          bool              `json:"user_added"`
+	Tags               []string          `json:"tags"`
+}
+
+// VocabFilter narrows ListVocabulary. Zero values match everything except
+// known words, which are only listed with IncludeKnown.
+type VocabFilter struct {
+	Language     string
+	Level        string
+	StoryID      int64
+	Tag          string
+	Search       string
+	IncludeKnown bool
+}
+
+// StorySummary identifies a stored story for filtering and display.
+type StorySummary struct {
+	ID        int64
+	Language  string
+	Level     string
+	Topic     string
+	CreatedAt time.Time
+}
+
+const vocabColumns = `v.vocab_id, v.language, v.word, v.translation, COALESCE(v.example_sentence, ''),
+	COALESCE(v.example_translation, ''), COALESCE(v.lemma, ''), COALESCE(v.part_of_speech, ''),
+	COALESCE(v.features, ''), COALESCE(v.pronunciation, ''), COALESCE(v.difficulty_level, ''), COALESCE(v.story_id, 0), COALESCE(s.topic, ''),
+	v.times_encountered, v.last_encountered, v.known, v.user_added,
+	COALESCE((SELECT group_concat(tag, ',') FROM vocabulary_tags t WHERE t.vocab_id = v.vocab_id), '')`
+
+// ListVocabulary returns notebook entries matching the filter, most
+// frequently encountered first. Search matches word, lemma or translation,
+// ignoring case.
+func (d *DB) ListVocabulary(filter VocabFilter) ([]VocabEntry, error) {
+	query := `SELECT ` + vocabColumns + ` FROM vocabulary v LEFT JOIN stories s ON v.story_id = s.story_id WHERE 1 = 1`
+	var args []interface{}
+
+	if filter.Language != "" {
+		query += ` AND v.language = ?`
+		args = append(args, filter.Language)
+	}
+	if filter.Level != "" {
+		query += ` AND v.difficulty_level = ?`
+		args = append(args, filter.Level)
+	}
+	if filter.StoryID != 0 {
+		query += ` AND v.story_id = ?`
+		args = append(args, filter.StoryID)
+	}
+	if filter.Tag != "" {
+		query += ` AND EXISTS (SELECT 1 FROM vocabulary_tags t WHERE t.vocab_id = v.vocab_id AND t.tag = ?)`
+		args = append(args, normalizeTag(filter.Tag))
+	}
+	if !filter.IncludeKnown {
+		query += ` AND v.known = 0`
+	}
+	query += ` ORDER BY v.times_encountered DESC, v.word`
+
+	rows, err := d.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// SQLite only folds ASCII case, so Cyrillic and Urdu search happens here
+	search := strings.ToLower(strings.TrimSpace(filter.Search))
+
+	var entries []VocabEntry
+	for rows.Next() {
+		entry, err := scanVocab(rows)
+		if err != nil {
+			return nil, err
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(entry.Word), search) &&
+			!strings.Contains(strings.ToLower(entry.Lemma), search) &&
+			!strings.Contains(strings.ToLower(entry.Translation), search) {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, rows.Err()
+}
+
+func (d *DB) GetVocabulary(id int64) (*VocabEntry, error) {
+	row := d.conn.QueryRow(`SELECT `+vocabColumns+` FROM vocabulary v LEFT JOIN stories s ON v.story_id = s.story_id
+		WHERE v.vocab_id = ?`, id)
+	entry, err := scanVocab(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no vocabulary entry with id %d", id)
+	}
+	return entry, err
+}
+
+// AddVocabulary stores a word entered by the learner and puts it in the
+// review deck. Adding a word that exists updates its translation instead.
+func (d *DB) AddVocabulary(entry VocabEntry) (int64, error) {
+	if entry.Word == "" || entry.Translation == "" {
+		return 0, fmt.Errorf("word and translation are required")
+	}
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if _, err := tx.Exec(`INSERT INTO vocabulary
+		(word, translation, example_sentence, language, difficulty_level, last_encountered, user_added)
+		VALUES (?, ?, NULLIF(?, ''), ?, ?, ?, 1)
+		ON CONFLICT(language, word) DO UPDATE SET
+			translation = excluded.translation,
+			example_sentence = COALESCE(excluded.example_sentence, example_sentence)`,
+		entry.Word, entry.Translation, entry.Example, entry.Language, entry.Level, formatTime(now)); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := tx.QueryRow(`SELECT vocab_id FROM vocabulary WHERE language = ? AND word = ?`,
+		entry.Language, entry.Word).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	for _, tag := range entry.Tags {
+		if err := addTag(tx, id, tag); err != nil {
+			return 0, err
+		}
+	}
+	if err := addReviewCards(tx, entry.Language, entry.Word, now); err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// UpdateVocabulary corrects the translation and example of an entry.
+// Empty values leave the field unchanged.
+func (d *DB) UpdateVocabulary(id int64, translation, example string) error {
+	return d.execOne(`UPDATE vocabulary SET
+		translation = COALESCE(NULLIF(?, ''), translation),
+		example_sentence = COALESCE(NULLIF(?, ''), example_sentence)
+		WHERE vocab_id = ?`, translation, example, id)
+}
+
+// SetKnown marks a word as known. Known words are left out of reviews and
+// of future story prompts.
+func (d *DB) SetKnown(id int64, known bool) error {
+	return d.execOne(`UPDATE vocabulary SET known = ? WHERE vocab_id = ?`, known, id)
+}
+
+func (d *DB) AddTag(id int64, tag string) error {
+	if _, err := d.GetVocabulary(id); err != nil {
+		return err
+	}
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := addTag(tx, id, tag); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (d *DB) RemoveTag(id int64, tag string) error {
+	_, err := d.conn.Exec(`DELETE FROM vocabulary_tags WHERE vocab_id = ? AND tag = ?`, id, normalizeTag(tag))
+	return err
+}
+
+// Tags returns every tag in use, sorted.
+func (d *DB) Tags() ([]string, error) {
+	rows, err := d.conn.Query(`SELECT DISTINCT tag FROM vocabulary_tags ORDER BY tag`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// KnownWords returns the words of a language marked as known, along with
+// their lemmas.
+func (d *DB) KnownWords(language string) ([]string, error) {
+	rows, err := d.conn.Query(`SELECT word FROM vocabulary WHERE language = ? AND known = 1
+		UNION SELECT lemma FROM vocabulary WHERE language = ? AND known = 1 AND lemma != ''
+		ORDER BY 1`, language, language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []string
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+	return words, rows.Err()
+}
+
+// Stories returns the stored stories of a language, newest first.
+func (d *DB) Stories(language string) ([]StorySummary, error) {
+	rows, err := d.conn.Query(`SELECT story_id, language, level, topic, created_at FROM stories
+		WHERE language = ? ORDER BY created_at DESC, story_id DESC`, language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stories []StorySummary
+	for rows.Next() {
+		var story StorySummary
+		// Imported stories may have no creation time
+		var createdAt sql.NullTime
+		if err := rows.Scan(&story.ID, &story.Language, &story.Level, &story.Topic, &createdAt); err != nil {
+			return nil, err
+		}
+		story.CreatedAt = createdAt.Time
+		stories = append(stories, story)
+	}
+	return stories, rows.Err()
+}
+
+// StoryText returns the text of a stored story.
+func (d *DB) StoryText(id int64) (string, error) {
+	var storyText string
+	err := d.conn.QueryRow(`SELECT story_text FROM stories WHERE story_id = ?`, id).Scan(&storyText)
+	return storyText, err
+}
+
+func (d *DB) execOne(query string, args ...interface{}) error {
+	result, err := d.conn.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("no vocabulary entry with id %v", args[len(args)-1])
+	}
+	return nil
+}
+
+func addTag(tx *sql.Tx, id int64, tag string) error {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return nil
+	}
+	_, err := tx.Exec(`INSERT OR IGNORE INTO vocabulary_tags (vocab_id, tag) VALUES (?, ?)`, id, tag)
+	return err
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanVocab(row scanner) (*VocabEntry, error) {
+	var entry VocabEntry
+	var features, tags string
+	var lastEncountered sql.NullTime
+	if err := row.Scan(&entry.ID, &entry.Language, &entry.Word, &entry.Translation, &entry.Example,
+		&entry.ExampleTranslation, &entry.Lemma, &entry.PartOfSpeech, &features, &entry.Pronunciation, &entry.Level, &entry.StoryID, &entry.StoryTopic, &entry.TimesEncountered, &lastEncountered,
+		&entry.Known, &entry.UserAdded, &tags); err != nil {
+		return nil, err
+	}
+	entry.LastEncountered = lastEncountered.Time
+
+	if features != "" {
+		if err := json.Unmarshal([]byte(features), &entry.Features); err != nil {
+			return nil, fmt.Errorf("invalid features of %q: %w", entry.Word, err)
+		}
+	}
+
+	entry.Tags = []string{}
+	if tags != "" {
+		entry.Tags = strings.Split(tags, ",")
+		sort.Strings(entry.Tags)
+	}
+	return &entry, nil
+}
@@ -0,0 +1,118 @@
+package history
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+)
+
+// Answer is the learner's response to one exercise.
+type Answer struct {
+	Type       string    `json:"type"`
+	Question   string    `json:"question"`
+	Expected   string    `json:"expected"`
+	Given      string    `json:"given"`
+	Choice     string    `json:"choice,omitempty"`
+	Correct    bool      `json:"correct"`
+	Almost     bool      `json:"almost,omitempty"`
+	Score      int       `json:"score,omitempty"`
+	Feedback   string    `json:"feedback,omitempty"`
+	Hints      int       `json:"hints,omitempty"`
+	ResponseMS int64     `json:"response_ms,omitempty"`
+	TimedOut   bool      `json:"timed_out,omitempty"`
+	Retry      int       `json:"retry,omitempty"` // retry round, 0 for the first try
+	Drill      bool      `json:"drill,omitempty"` // vocabulary drill, not scored
+	AnsweredAt time.Time `json:"answered_at"`
+}
+
+// Session is the record of one learning session.
+type Session struct {
+	ID          string            `json:"id"`
+	Language    string            `json:"language"`
+	Level       string            `json:"level"`
+	Topic       string            `json:"topic"`
+	Story       *ai.StoryResponse `json:"story"`
+	Answers     []Answer          `json:"answers"`
+	Score       int               `json:"score"`
+	Total       int               `json:"total"`
+	Eventual    int               `json:"eventual_score,omitempty"` // correct on the first try or a retry
+	HintPenalty float64           `json:"hint_penalty,omitempty"`
+	StartedAt   time.Time         `json:"started_at"`
+	CompletedAt time.Time         `json:"completed_at"`
+}
+
+// Store persists learning sessions.
+type Store interface {
+	Save(session *Session) error
+	Get(id string) (*Session, error)
+	// List returns all sessions, most recent first.
+	List() ([]*Session, error)
+}
+
+func NewSession(language, level, topic string) *Session {
+	now := time.Now()
+	return &Session{
+		ID:        newID(now),
+		Language:  language,
+		Level:     level,
+		Topic:     topic,
+		StartedAt: now,
+	}
+}
+
+// HintCost is the part of an exercise's point each hint costs.
+const HintCost = 0.25
+
+// Record adds an answer. Only first tries of exercises count towards the
+// score, less the hints used for them.
+func (s *Session) Record(answer Answer) {
+	s.Answers = append(s.Answers, answer)
+	if answer.Retry > 0 || answer.Drill {
+		return
+	}
+	s.Total++
+	if answer.Correct {
+		s.Score++
+		s.Eventual++
+		s.HintPenalty += min(1, HintCost*float64(answer.Hints))
+	}
+}
+
+// Points is the score less the cost of hints.
+func (s *Session) Points() float64 {
+	return float64(s.Score) - s.HintPenalty
+}
+
+// Fixed counts a missed exercise answered correctly in a retry round.
+func (s *Session) Fixed() {
+	s.Eventual++
+}
+
+// EventualScore is the number of exercises answered correctly in the end.
+// Sessions saved before retry rounds only have their first-try score.
+func (s *Session) EventualScore() int {
+	return max(s.Eventual, s.Score)
+}
+
+// DrillScore counts the correct and total answers of the vocabulary drill.
+func (s *Session) DrillScore() (correct, total int) {
+	for _, answer := range s.Answers {
+		if !answer.Drill {
+			continue
+		}
+		total++
+		if answer.Correct {
+			correct++
+		}
+	}
+	return correct, total
+}
+
+func newID(now time.Time) string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return fmt.Sprintf("session_%d_%s", now.Unix(), hex.EncodeToString(suffix))
+}
@@ -0,0 +1,169 @@
+// Package plugin runs external exercise plugins: executables in the plugins
+// directory that add exercises to stories and grade answers to them over a
+// line-delimited JSON protocol on stdin and stdout.
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+)
+
+// Timeouts for each request type. A plugin still running after its timeout
+// is killed and the request fails.
+var Timeouts = map[string]time.Duration{
+	Describe: 2 * time.Second,
+	Generate: 10 * time.Second,
+	Grade:    3 * time.Second,
+}
+
+// maxResponseSize bounds how much of a plugin's output is read.
+const maxResponseSize = 1 << 20
+
+// Plugin is an executable found in the plugins directory.
+type Plugin struct {
+	Path          string
+	Name          string
+	Description   string
+	ExerciseTypes []string
+}
+
+// Discover describes every executable file in dir, sorted by file name.
+// Plugins that fail to describe themselves are returned as errors and left
+// out. A missing directory has no plugins.
+func Discover(dir string) ([]*Plugin, []error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var names []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0 {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var plugins []*Plugin
+	var errs []error
+	for _, name := range names {
+		p := &Plugin{Path: filepath.Join(dir, name), Name: name}
+		resp, err := p.call(Request{Type: Describe})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if resp.Name != "" {
+			p.Name = resp.Name
+		}
+		p.Description = resp.Description
+		p.ExerciseTypes = resp.ExerciseTypes
+		plugins = append(plugins, p)
+	}
+	return plugins, errs
+}
+
+// Generate asks the plugin for extra exercises for a story.
+func (p *Plugin) Generate(story *ai.StoryResponse, language, level string) ([]ai.Exercise, error) {
+	resp, err := p.call(Request{Type: Generate, Language: language, Level: level, Story: story})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Exercises, nil
+}
+
+// Grade asks the plugin to grade an answer to one of its exercises.
+func (p *Plugin) Grade(exercise ai.Exercise, answer, language string) (*Response, error) {
+	return p.call(Request{Type: Grade, Language: language, Exercise: &exercise, Answer: answer})
+}
+
+// call runs the plugin for a single request in a fresh temporary directory,
+// which is also its HOME, with a minimal environment.
+func (p *Plugin) call(req Request) (*Response, error) {
+	line, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "polyglot-plugin-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeouts[req.Type])
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Dir = workDir
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + workDir,
+		"TMPDIR=" + workDir,
+		"LANG=C.UTF-8",
+	}
+	cmd.Stdin = bytes.NewReader(append(line, '\n'))
+	cmd.WaitDelay = time.Second
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &limitedBuffer{buf: &stdout, limit: maxResponseSize}
+	cmd.Stderr = &limitedBuffer{buf: &stderr, limit: 4096}
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("plugin %s: %s timed out after %s", p.Name, req.Type, Timeouts[req.Type])
+		}
+		if msg := bytes.TrimSpace(stderr.Bytes()); len(msg) > 0 {
+			return nil, fmt.Errorf("plugin %s: %w: %s", p.Name, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+	}
+
+	// The response is the first non-empty line of output
+	scanner := bufio.NewScanner(&stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxResponseSize)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var resp Response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			return nil, fmt.Errorf("plugin %s: invalid %s response: %w", p.Name, req.Type, err)
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("plugin %s: %s", p.Name, resp.Error)
+		}
+		return &resp, nil
+	}
+	return nil, fmt.Errorf("plugin %s: no response to %s", p.Name, req.Type)
+}
+
+// limitedBuffer keeps the first limit bytes written and drops the rest.
+type limitedBuffer struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		if len(p) > room {
+			b.buf.Write(p[:room])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+)
+
+// Request types sent to plugins, one JSON object per line on stdin.
+const (
+	Describe = "describe"
+	Generate = "generate"
+	Grade    = "grade"
+)
+
+// Request is a message to a plugin. Only the fields of its type are set.
+type Request struct {
+	Type     string            `json:"type"`
+	Language string            `json:"language,omitempty"`
+	Level    string            `json:"level,omitempty"`
+	Story    *ai.StoryResponse `json:"story,omitempty"`
+	Exercise *ai.Exercise      `json:"exercise,omitempty"`
+	Answer   string            `json:"answer,omitempty"`
+}
+
+// Response is a plugin's reply, one JSON object on a line of stdout.
+// A non-empty Error fails the request.
+type Response struct {
+	Error string `json:"error,omitempty"`
+
+	// describe
+	Name          string   `json:"name,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	ExerciseTypes []string `json:"exercise_types,omitempty"`
+
+	// generate
+	Exercises []ai.Exercise `json:"exercises,omitempty"`
+
+	// grade
+	Correct     bool   `json:"correct"`
+	Almost      bool   `json:"almost,omitempty"`
+	Explanation string `json:"explanation,omitempty"`
+}
@@ -0,0 +1,136 @@
+package progress
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Day records the sessions completed on one calendar day and the goal that
+// was in effect, so changing the goal later does not rewrite past streaks.
+type Day struct {
+	Completed int `json:"completed"`
+	Goal      int `json:"goal"`
+}
+
+func (d Day) GoalMet() bool {
+	return d.Goal > 0 && d.Completed >= d.Goal
+}
+
+// Tracker persists completed sessions per local calendar day.
+type Tracker struct {
+	file string
+	days map[string]Day
+}
+
+func NewTracker(dataDir string) *Tracker {
+	return &Tracker{
+		file: filepath.Join(dataDir, "progress.json"),
+		days: map[string]Day{},
+	}
+}
+
+func (t *Tracker) Load() error {
+	data, err := os.ReadFile(t.file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &t.days)
+}
+
+func (t *Tracker) save() error {
+	if err := os.MkdirAll(filepath.Dir(t.file), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t.days, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.file, data, 0644)
+}
+
+// RecordSession counts a completed session for the day of now and reports
+// whether this session is the one that reached the goal.
+func (t *Tracker) RecordSession(now time.Time, goal int) (bool, error) {
+	key := dayKey(now)
+	day := t.days[key]
+	wasMet := day.GoalMet()
+
+	day.Completed++
+	day.Goal = goal
+	t.days[key] = day
+
+	return !wasMet && day.GoalMet(), t.save()
+}
+
+// Today returns the record for the day of now, using goal if nothing was
+// completed yet.
+func (t *Tracker) Today(now time.Time, goal int) Day {
+	day, ok := t.days[dayKey(now)]
+	if !ok {
+		day.Goal = goal
+	}
+	return day
+}
+
+// Streak returns the number of consecutive days up to now on which the goal
+// was met. An unfinished today does not break the streak.
+func (t *Tracker) Streak(now time.Time) int {
+	date := localDate(now)
+	if !t.days[date.Format(dateLayout)].GoalMet() {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for t.days[date.Format(dateLayout)].GoalMet() {
+		streak++
+		date = date.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// BestStreak returns the longest run of consecutive days with the goal met.
+func (t *Tracker) BestStreak() int {
+	var keys []string
+	for key, day := range t.days {
+		if day.GoalMet() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	best, current := 0, 0
+	var previous time.Time
+	for _, key := range keys {
+		date, err := time.ParseInLocation(dateLayout, key, time.Local)
+		if err != nil {
+			continue
+		}
+		if current > 0 && previous.AddDate(0, 0, 1).Equal(date) {
+			current++
+		} else {
+			current = 1
+		}
+		if current > best {
+			best = current
+		}
+		previous = date
+	}
+	return best
+}
+
+func dayKey(now time.Time) string {
+	return now.Local().Format(dateLayout)
+}
+
+func localDate(now time.Time) time.Time {
+	now = now.Local()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
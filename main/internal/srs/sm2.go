@@ -0,0 +1,85 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+// Card holds the SM-2 scheduling state of one flashcard.
+type Card struct {
+	EaseFactor  float64
+	Interval    int
+	Repetitions int
+	Due         time.Time
+}
+
+// Recall quality grades as defined by SM-2.
+const (
+	QualityBlackout = 0
+	QualityWrong    = 1
+	QualityHard     = 3
+	QualityGood     = 4
+	QualityEasy     = 5
+)
+
+const (
+	initialEase = 2.5
+	minimumEase = 1.3
+)
+
+// NewCard returns a card that is due immediately.
+func NewCard(now time.Time) Card {
+	return Card{
+		EaseFactor: initialEase,
+		Due:        Day(now),
+	}
+}
+
+// Review schedules the next repetition after an answer of the given quality
+// (0-5). Answers below 3 restart the card; it is shown again tomorrow.
+func (c Card) Review(quality int, now time.Time) Card {
+	if quality < 0 {
+		quality = 0
+	}
+	if quality > 5 {
+		quality = 5
+	}
+	if c.EaseFactor == 0 {
+		c.EaseFactor = initialEase
+	}
+
+	if quality < 3 {
+		c.Repetitions = 0
+		c.Interval = 1
+	} else {
+		switch c.Repetitions {
+		case 0:
+			c.Interval = 1
+		case 1:
+			c.Interval = 6
+		default:
+			c.Interval = int(math.Round(float64(c.Interval) * c.EaseFactor))
+		}
+		c.Repetitions++
+	}
+
+	q := float64(5 - quality)
+	c.EaseFactor += 0.1 - q*(0.08+q*0.02)
+	if c.EaseFactor < minimumEase {
+		c.EaseFactor = minimumEase
+	}
+
+	c.Due = Day(now).AddDate(0, 0, c.Interval)
+	return c
+}
+
+// IsDue reports whether the card should be reviewed on the day of now.
+func (c Card) IsDue(now time.Time) bool {
+	return !c.Due.After(Day(now))
+}
+
+// Day truncates t to midnight of its local calendar day.
+func Day(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
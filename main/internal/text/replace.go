@@ -0,0 +1,121 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// span is a word of a text as rune offsets, without surrounding hyphens
+// and apostrophes.
+type span struct{ start, end int }
+
+// wordSpans splits runes into words as in Words.
+func wordSpans(runes []rune) []span {
+	var spans []span
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && isWordRune(runes[i]) {
+			i++
+		}
+		end := i
+		for start < end && isWordEdge(runes[start]) {
+			start++
+		}
+		for end > start && isWordEdge(runes[end-1]) {
+			end--
+		}
+		if start < end {
+			spans = append(spans, span{start, end})
+		}
+	}
+	return spans
+}
+
+// ReplacePhrases rewrites every occurrence of the phrases in text,
+// ignoring case and leaving spacing and punctuation around them untouched.
+// A phrase of several words matches those words separated by spaces only.
+// Where phrases overlap, the longest one wins.
+func ReplacePhrases(text string, phrases []string, replace func(match string) string) string {
+	var wanted [][]string
+	for _, phrase := range phrases {
+		if words := Words(phrase); len(words) > 0 {
+			wanted = append(wanted, words)
+		}
+	}
+
+	runes := []rune(text)
+	spans := wordSpans(runes)
+	var out strings.Builder
+	last := 0
+	for i := 0; i < len(spans); {
+		n := longestMatch(runes, spans[i:], wanted)
+		if n == 0 {
+			i++
+			continue
+		}
+		start, end := spans[i].start, spans[i+n-1].end
+		out.WriteString(string(runes[last:start]))
+		out.WriteString(replace(string(runes[start:end])))
+		last = end
+		i += n
+	}
+	out.WriteString(string(runes[last:]))
+	return out.String()
+}
+
+// longestMatch returns how many of the words starting at spans[0] make up
+// the longest matching phrase, or 0.
+func longestMatch(runes []rune, spans []span, phrases [][]string) int {
+	best := 0
+	for _, phrase := range phrases {
+		if len(phrase) <= best || len(phrase) > len(spans) {
+			continue
+		}
+		matched := true
+		for k, word := range phrase {
+			if k > 0 && strings.TrimSpace(string(runes[spans[k-1].end:spans[k].start])) != "" {
+				matched = false
+				break
+			}
+			if !strings.EqualFold(string(runes[spans[k].start:spans[k].end]), word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			best = len(phrase)
+		}
+	}
+	return best
+}
+
+// Highlight marks each occurrence of the given words or phrases in text,
+// ignoring case.
+func Highlight(text string, words []string, mark func(word string) string) string {
+	return ReplacePhrases(text, words, mark)
+}
+
+// Blank replaces each occurrence of a word or phrase in sentence with a
+// gap and reports whether there was one to replace.
+func Blank(sentence, phrase, gap string) (string, bool) {
+	found := false
+	blanked := ReplacePhrases(sentence, []string{phrase}, func(string) string {
+		found = true
+		return gap
+	})
+	return blanked, found
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && (!unicode.IsPunct(r) || isWordEdge(r))
+}
+
+// isWordEdge reports whether r belongs to a word inside it but not at its
+// edges.
+func isWordEdge(r rune) bool {
+	return r == '-' || r == '\'' || r == '’'
+}
@@ -0,0 +1,58 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Sentences splits text into sentences ending in any of the delimiters.
+// Trailing text without a delimiter is returned as the last sentence.
+func Sentences(text, delimiters string) []string {
+	var sentences []string
+	var current strings.Builder
+
+	flush := func() {
+		if sentence := strings.TrimSpace(current.String()); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		current.Reset()
+	}
+
+	runes := []rune(text)
+	ending := false
+	for i, r := range runes {
+		current.WriteRune(r)
+		ending = strings.ContainsRune(delimiters, r) || (ending && isClosing(r))
+		if !ending {
+			continue
+		}
+		// Keep runs like "?!" or "..." and closing quotes with the sentence
+		if i+1 < len(runes) && (strings.ContainsRune(delimiters, runes[i+1]) || isClosing(runes[i+1])) {
+			continue
+		}
+		flush()
+		ending = false
+	}
+	flush()
+
+	return sentences
+}
+
+// Words splits text into words, dropping surrounding punctuation.
+// Hyphens and apostrophes inside a word are kept.
+func Words(text string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || (unicode.IsPunct(r) && r != '-' && r != '\'' && r != '’')
+	}) {
+		field = strings.Trim(field, "-'’")
+		if field != "" {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+func isClosing(r rune) bool {
+	return strings.ContainsRune(`"'»”’)`, r)
+}
@@ -0,0 +1,73 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wordStart marks table keys that only match at the start of a word, for
+// scripts that write a word-initial vowel differently.
+const wordStart = "^"
+
+// Phonetic converts Latin typing into another script using a table of
+// Latin sequences, always taking the longest sequence that matches.
+// Sequences are looked up as typed first, then in lowercase with the
+// result capitalised, so tables only need uppercase keys where case
+// changes the letter. At the start of a word, keys prefixed with ^ win
+// over the plain ones. Text without a match is kept as typed.
+func Phonetic(text string, table map[string]string) string {
+	longest := 0
+	for key := range table {
+		if n := utf8.RuneCountInString(strings.TrimPrefix(key, wordStart)); n > longest {
+			longest = n
+		}
+	}
+
+	var out strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		atStart := i == 0 || !unicode.IsLetter(runes[i-1])
+		matched := false
+		for n := min(longest, len(runes)-i); n > 0; n-- {
+			chunk := string(runes[i : i+n])
+			script, ok := "", false
+			if atStart {
+				script, ok = lookup(table, wordStart+chunk)
+			}
+			if !ok {
+				script, ok = lookup(table, chunk)
+			}
+			if !ok {
+				continue
+			}
+			out.WriteString(script)
+			i += n
+			matched = true
+			break
+		}
+		if !matched {
+			out.WriteRune(runes[i])
+			i++
+		}
+	}
+	return out.String()
+}
+
+// lookup finds a sequence as typed, then in lowercase with the result
+// capitalised when the sequence starts with a capital.
+func lookup(table map[string]string, chunk string) (string, bool) {
+	if script, ok := table[chunk]; ok {
+		return script, true
+	}
+	script, ok := table[strings.ToLower(chunk)]
+	if ok && unicode.IsUpper([]rune(strings.TrimPrefix(chunk, wordStart))[0]) {
+		script = capitalize(script)
+	}
+	return script, ok
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,50 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// russianLetters follows the BGN/PCGN romanization of Russian.
+var russianLetters = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "ë",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "”", 'ы': "y", 'ь': "’", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// Russian transliterates Cyrillic using BGN/PCGN. Е and ё are written ye
+// and yë at the start of a word and after a vowel, й, ъ or ь.
+func Russian(text string) string {
+	var out strings.Builder
+	runes := []rune(text)
+
+	for i, r := range runes {
+		lower := unicode.ToLower(r)
+		latin, ok := russianLetters[lower]
+		if !ok {
+			out.WriteRune(r)
+			continue
+		}
+
+		if (lower == 'е' || lower == 'ё') && (i == 0 || softensE(runes[i-1])) {
+			latin = "y" + latin
+		}
+
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		out.WriteString(matchCase(latin, r, next))
+	}
+	return out.String()
+}
+
+func softensE(previous rune) bool {
+	previous = unicode.ToLower(previous)
+	if _, cyrillic := russianLetters[previous]; !cyrillic {
+		return true
+	}
+	return strings.ContainsRune("аеёиоуыэюяйъь", previous)
+}
@@ -0,0 +1,60 @@
+// Package translit writes Cyrillic and Urdu text in Latin letters for
+// learners who can't read the script yet.
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+var schemes = map[string]func(string) string{
+	"bgn-pcgn":   Russian,
+	"roman-urdu": Urdu,
+}
+
+// Supported reports whether scheme names a known transliteration.
+func Supported(scheme string) bool {
+	_, ok := schemes[scheme]
+	return ok
+}
+
+// Transliterate writes text in Latin letters using the named scheme.
+// Text is returned unchanged for unknown schemes.
+func Transliterate(scheme, text string) string {
+	if convert, ok := schemes[scheme]; ok {
+		return convert(text)
+	}
+	return text
+}
+
+// Fold reduces transliterated text to plain lowercase ASCII-like letters so
+// that typed answers match without diacritics or soft and hard sign marks.
+func Fold(text string) string {
+	var out strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch r {
+		case 'ë':
+			out.WriteRune('e')
+		case '’', '”', '\'', '"', '`', '·':
+		default:
+			if unicode.IsPunct(r) {
+				continue
+			}
+			out.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(out.String()), " ")
+}
+
+// matchCase writes latin in the case of the original letter: capitalised
+// before a lowercase letter, otherwise all caps.
+func matchCase(latin string, original, next rune) string {
+	if latin == "" || !unicode.IsUpper(original) {
+		return latin
+	}
+	if unicode.IsLower(next) {
+		runes := []rune(latin)
+		return strings.ToUpper(string(runes[0])) + string(runes[1:])
+	}
+	return strings.ToUpper(latin)
+}
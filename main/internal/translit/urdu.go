@@ -0,0 +1,66 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// urduLetters maps Urdu letters to common Roman Urdu spellings. Urdu
+// script leaves out most short vowels, so the result is a reading aid
+// rather than an exact pronunciation.
+var urduLetters = map[rune]string{
+	'ا': "a", 'آ': "aa", 'ب': "b", 'پ': "p", 'ت': "t", 'ٹ': "t", 'ث': "s",
+	'ج': "j", 'چ': "ch", 'ح': "h", 'خ': "kh", 'د': "d", 'ڈ': "d", 'ذ': "z",
+	'ر': "r", 'ڑ': "r", 'ز': "z", 'ژ': "zh", 'س': "s", 'ش': "sh", 'ص': "s",
+	'ض': "z", 'ط': "t", 'ظ': "z", 'ع': "'", 'غ': "gh", 'ف': "f", 'ق': "q",
+	'ک': "k", 'ك': "k", 'گ': "g", 'ل': "l", 'م': "m", 'ن': "n", 'ں': "n",
+	'و': "o", 'ہ': "h", 'ه': "h", 'ۂ': "h", 'ھ': "h", 'ء': "'", 'ئ': "",
+	'ی': "i", 'ي': "i", 'ے': "e", 'ۓ': "e", 'ة': "t",
+	'َ': "a", 'ِ': "i", 'ُ': "u", 'ً': "an",
+	'۔': ".", '؟': "?", '،': ",", '؛': ";",
+	'۰': "0", '۱': "1", '۲': "2", '۳': "3", '۴': "4",
+	'۵': "5", '۶': "6", '۷': "7", '۸': "8", '۹': "9",
+}
+
+// Urdu transliterates Urdu script to Roman Urdu.
+func Urdu(text string) string {
+	var out strings.Builder
+	runes := []rune(text)
+	last := ""
+
+	for i, r := range runes {
+		initial := i == 0 || !isUrduLetter(runes[i-1])
+		final := i+1 == len(runes) || !isUrduLetter(runes[i+1])
+
+		latin, ok := urduLetters[r]
+		switch {
+		case r == 'ّ':
+			// Shadda doubles the previous consonant
+			latin, ok = last, true
+		case r == 'ْ' || r == 'ٰ':
+			latin, ok = "", true
+		case r == 'ا' && !initial:
+			latin = "aa"
+		case r == 'و' && initial:
+			latin = "w"
+		case (r == 'ی' || r == 'ي') && initial:
+			latin = "y"
+		case r == 'ی' && !final && i+1 < len(runes) && runes[i+1] == 'ں':
+			latin = "ai"
+		case (r == 'ہ' || r == 'ه') && final && !initial:
+			latin = "a"
+		}
+		if !ok {
+			out.WriteRune(r)
+			last = ""
+			continue
+		}
+		out.WriteString(latin)
+		last = latin
+	}
+	return out.String()
+}
+
+func isUrduLetter(r rune) bool {
+	return unicode.Is(unicode.Arabic, r) && unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/translit"
+)
+
+// checkAnswer compares an answer with the accepted ones using the language's
+// normalization rules and the configured typo tolerance. With transliteration
+// on, the answer may also be typed in Latin letters.
+func (a *App) checkAnswer(langInfo config.Language, given string, accepted ...string) answer.Match {
+	checker := answer.Checker{Language: langInfo, Tolerance: a.currentConfig.AnswerTolerance}
+	match := checker.Check(given, accepted...)
+	if match.Result == answer.Correct {
+		return match
+	}
+
+	for _, expected := range accepted {
+		if latin := a.transliterate(langInfo, expected); latin != "" && translit.Fold(given) == translit.Fold(latin) {
+			return answer.Match{Result: answer.Correct, Expected: expected}
+		}
+	}
+	return match
+}
+
+// AI grades from which an acceptable answer counts as correct, or is at
+// least reported as almost correct, which doesn't score. Lower grades are
+// wrong whatever the verdict.
+const (
+	aiAcceptScore = 90
+	aiAlmostScore = 60
+)
+
+// gradeFreeText asks the AI to grade an answer the local check rejected,
+// since free text can be right in many ways. The local match stands when
+// the AI is unavailable.
+func (a *App) gradeFreeText(ex *practice, given string, local answer.Match) answer.Match {
+	if local.Result == answer.Correct || strings.TrimSpace(given) == "" {
+		return local
+	}
+
+	fmt.Printf("%s🤖 Checking your answer...%s\n", ColorInfo, ColorReset)
+	grade, err := a.aiClient.GradeAnswer(ex.Language, a.currentConfig.NativeLanguage, ex.Question, ex.Answer, given)
+	if err != nil {
+		a.printWarning("AI grading unavailable, checked locally: " + err.Error())
+		return local
+	}
+
+	match := answer.Match{Result: answer.Wrong, Expected: local.Expected, Explanation: grade.Explanation, Score: grade.Score}
+	switch {
+	case grade.Acceptable && grade.Score >= aiAcceptScore:
+		match.Result = answer.Correct
+	case grade.Acceptable && grade.Score >= aiAlmostScore:
+		match.Result = answer.Almost
+	}
+	return match
+}
+
+// feedback appends the grader's explanation to a line about the answer.
+func feedback(line string, match answer.Match) string {
+	if match.Explanation == "" {
+		return line
+	}
+	return fmt.Sprintf("%s\n   💬 %s (%d/100)", line, match.Explanation, match.Score)
+}
+
+// printMatch tells the learner how their answer went.
+func (a *App) printMatch(match answer.Match) {
+	switch match.Result {
+	case answer.Correct:
+		fmt.Printf("%s✅ Correct!%s\n", ColorSuccess, ColorReset)
+	case answer.Almost:
+		fmt.Printf("%s🟡 Almost correct! Watch the spelling: %s%s\n", ColorWarning, match.Expected, ColorReset)
+	default:
+		fmt.Printf("%s❌ The answer is: %s%s\n", ColorError, match.Expected, ColorReset)
+	}
+}
+
+// transliterate returns text in Latin letters, or "" when transliteration is
+// off or the language has no scheme.
+func (a *App) transliterate(langInfo config.Language, text string) string {
+	if !a.currentConfig.Transliteration || langInfo.Transliteration == "" {
+		return ""
+	}
+	return translit.Transliterate(langInfo.Transliteration, text)
+}
+
+func (a *App) latinSuffix(langInfo config.Language, word string) string {
+	if latin := a.transliterate(langInfo, word); latin != "" {
+		return " (" + latin + ")"
+	}
+	return ""
+}
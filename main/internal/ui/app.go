@@ -5,8 +5,11 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/history"
 )
 
 func (a *App) Run() error {
@@ -26,6 +29,23 @@ func (a *App) Run() error {
 	}
 	a.currentConfig = cfg
 
+	if err := a.configManager.LoadLanguages(); err != nil {
+		return fmt.Errorf("failed to load languages: %w", err)
+	}
+
+	a.loadPlugins()
+
+	if err := a.progress.Load(); err != nil {
+		return fmt.Errorf("failed to load progress: %w", err)
+	}
+
+	// The progress database is optional; sessions still run without it
+	if err := a.openStore(); err != nil {
+		a.printWarning("Progress database unavailable: " + err.Error())
+	} else {
+		defer a.store.Close()
+	}
+
 	a.printHeader()
 	a.printStatus("⚙️", "Initializing "+config.AppName+" v"+config.Version+"...")
 	a.printSuccess("Application ready")
@@ -33,7 +53,7 @@ func (a *App) Run() error {
 	// Main application loop
 	for {
 		a.showMainMenu()
-		choice, quit := a.getUserChoice("Choose option (1-5): ", 1, 5)
+		choice, quit := a.getUserChoice("Choose option (1-8): ", 1, 8)
 		if quit {
 			break
 		}
@@ -46,21 +66,35 @@ func (a *App) Run() error {
 				a.printSuccess("Learning session completed successfully")
 			}
 		case 2:
+			if err := a.reviewVocabulary(); err != nil {
+				a.printError("Vocabulary review failed: " + err.Error())
+			}
+		case 3:
+			if err := a.showVocabularyNotebook(); err != nil {
+				a.printError("Vocabulary notebook failed: " + err.Error())
+			}
+		case 4:
 			if err := a.selectLanguage(); err != nil {
 				a.printError("Failed to change language: " + err.Error())
 			}
-		case 3:
+		case 5:
 			if err := a.selectLevel(); err != nil {
 				a.printError("Failed to change level: " + err.Error())
 			}
-		case 4:
-			a.showSettings()
-		case 5:
+		case 6:
+			if err := a.showSettings(); err != nil {
+				a.printError("Failed to save settings: " + err.Error())
+			}
+		case 7:
+			if err := a.showHistory(); err != nil {
+				a.printError("Failed to open history: " + err.Error())
+			}
+		case 8:
 			a.printSuccess("Happy learning! 👋")
 			return nil
 		}
 
-		if choice != 5 {
+		if choice != 8 {
 			a.waitForInput()
 		}
 	}
@@ -77,26 +111,139 @@ func (a *App) startLearningSession() error {
 
 	a.printStatus("🚀", "Starting "+a.currentConfig.Level+" "+a.currentConfig.Language+" session: "+topic)
 
-	story, err := a.aiClient.GenerateStory(a.currentConfig.Language, a.currentConfig.Level, topic)
+	langInfo, ok := config.Languages[a.currentConfig.Language]
+	if !ok {
+		return fmt.Errorf("unknown language %q", a.currentConfig.Language)
+	}
+	levelInfo, ok := config.Levels[a.currentConfig.Level]
+	if !ok {
+		return fmt.Errorf("unknown level %q", a.currentConfig.Level)
+	}
+	var knownWords []string
+	if a.store != nil {
+		knownWords, err = a.store.KnownWords(langInfo.Key)
+		if err != nil {
+			a.printWarning("Could not load known words: " + err.Error())
+		}
+	}
+
+	story, err := a.aiClient.GenerateStory(langInfo, levelInfo, topic, knownWords)
 	if err != nil {
 		return fmt.Errorf("failed to generate story content: %w", err)
 	}
 
-	return a.displayStory(story, a.currentConfig.Language, a.currentConfig.Level, topic)
+	a.addPluginExercises(story)
+
+	session := history.NewSession(a.currentConfig.Language, a.currentConfig.Level, topic)
+	session.Story = story
+	return a.runSession(session)
 }
 
-func (a *App) showSettings() {
-	a.printHeader()
-	fmt.Println(ColorPrimary.Render("⚙️ Settings"))
-	fmt.Println("══════════════════════════════════════════════════════════════════")
-	fmt.Println()
+func (a *App) runSession(session *history.Session) error {
+	record := a.recordStory(session)
+	if err := a.displayStory(session, record); err != nil {
+		return err
+	}
+
+	session.CompletedAt = time.Now()
+	if err := a.history.Save(session); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	if err := record.finish(session); err != nil {
+		a.printWarning("Progress not saved: " + err.Error())
+	}
+
+	goalReached, err := a.progress.RecordSession(time.Now(), a.currentConfig.DailyGoal)
+	if err != nil {
+		return fmt.Errorf("failed to record progress: %w", err)
+	}
+	if goalReached {
+		a.printSuccess(fmt.Sprintf("Daily goal reached! 🎯 %d-day streak 🔥", a.progress.Streak(time.Now())))
+	}
+	return nil
+}
 
-	langInfo := config.Languages[a.currentConfig.Language]
+func (a *App) openStore() error {
+	store, userID, err := db.OpenLocal(a.configManager.DatabaseFile())
+	if err != nil {
+		return err
+	}
+	a.store, a.userID = store, userID
+	return nil
+}
+
+func (a *App) showSettings() error {
+	for {
+		a.printHeader()
+		fmt.Println(ColorPrimary.Render("⚙️ Settings"))
+		fmt.Println("══════════════════════════════════════════════════════════════════")
+		fmt.Println()
+
+		langInfo := config.Languages[a.currentConfig.Language]
+
+		fmt.Printf("🌍 %sCurrent Language:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, langInfo.Display, ColorReset)
+		fmt.Printf("📊 %sCurrent Level:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.Level, ColorReset)
+		fmt.Printf("🔤 %sAuto-translate:%s %s%v%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.AutoTranslate, ColorReset)
+		fmt.Printf("🔡 %sTransliteration:%s %s%v%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.Transliteration, ColorReset)
+		fmt.Printf("⌨️ %sPhonetic Input:%s %s%v%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.PhoneticInput, ColorReset)
+		fmt.Printf("🏆 %sMastery Mode:%s %s%v%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.MasteryMode, ColorReset)
+		fmt.Printf("⏱️ %sTimed Mode:%s %s%v%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.TimedMode, ColorReset)
+		fmt.Printf("🏠 %sNative Language:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.NativeLanguage, ColorReset)
+		fmt.Printf("🎯 %sDaily Goal:%s %s%d story/day%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.DailyGoal, ColorReset)
+
+		today := a.progress.Today(time.Now(), a.currentConfig.DailyGoal)
+		fmt.Printf("📅 %sToday:%s %s%d/%d stories%s\n", ColorText, ColorReset, ColorAccent, today.Completed, today.Goal, ColorReset)
+		fmt.Printf("🔥 %sCurrent Streak:%s %s%d days%s\n", ColorText, ColorReset, ColorAccent, a.progress.Streak(time.Now()), ColorReset)
+		fmt.Printf("🏆 %sBest Streak:%s %s%d days%s\n", ColorText, ColorReset, ColorAccent, a.progress.BestStreak(), ColorReset)
+		fmt.Println()
+
+		fmt.Printf("   %s1. 🔤 Toggle auto-translate%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s2. 🔡 Toggle transliteration%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s3. ⌨️ Toggle phonetic input%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s4. 🏆 Toggle mastery mode%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s5. ⏱️ Toggle timed mode%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s6. 🎯 Change daily goal%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s7. 🏠 Change native language%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s8. ↩️ Back%s\n", ColorText, ColorReset)
+		fmt.Println()
+
+		choice, quit := a.getUserChoice("Choose option (1-8): ", 1, 8)
+		if quit || choice == 8 {
+			return nil
+		}
 
-	fmt.Printf("🌍 %sCurrent Language:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, langInfo.Display, ColorReset)
-	fmt.Printf("📊 %sCurrent Level:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.Level, ColorReset)
-	fmt.Printf("🔤 %sAuto-translate:%s %s%v%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.AutoTranslate, ColorReset)
-	fmt.Printf("🎯 %sDaily Goal:%s %s%d story/day%s\n", ColorText, ColorReset, ColorAccent, a.currentConfig.DailyGoal, ColorReset)
+		switch choice {
+		case 1:
+			a.currentConfig.AutoTranslate = !a.currentConfig.AutoTranslate
+		case 2:
+			a.currentConfig.Transliteration = !a.currentConfig.Transliteration
+		case 3:
+			a.currentConfig.PhoneticInput = !a.currentConfig.PhoneticInput
+		case 4:
+			a.currentConfig.MasteryMode = !a.currentConfig.MasteryMode
+		case 5:
+			a.currentConfig.TimedMode = !a.currentConfig.TimedMode
+		case 6:
+			goal, quit := a.getUserChoice(fmt.Sprintf("Stories per day (1-%d): ", config.MaxDailyGoal), 1, config.MaxDailyGoal)
+			if quit {
+				continue
+			}
+			a.currentConfig.DailyGoal = goal
+		case 7:
+			name := a.readLine("Native language for feedback: ")
+			if name == "" {
+				continue
+			}
+			field, _ := config.LookupField("native_language")
+			if err := field.Set(a.currentConfig, name); err != nil {
+				a.printWarning(err.Error())
+				a.waitForInput()
+				continue
+			}
+		}
 
-	a.waitForInput()
+		if err := a.configManager.Save(a.currentConfig); err != nil {
+			return err
+		}
+	}
 }
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/translit"
+)
+
+// multipleChoice shows options with letter labels and accepts the label or
+// the option itself.
+type multipleChoice struct{}
+
+func (multipleChoice) Valid(ex ai.Exercise) bool {
+	return len(ex.Options) > 0
+}
+
+func (multipleChoice) Render(a *App, ex *practice) {
+	// A story that kept an answer outside its options still gets a fair question
+	if ex.AnswerOption(ex.Language) < 0 {
+		ex.Options = append(slices.Clone(ex.Options), ex.Answer)
+	}
+	ex.Options = shuffleOptions(ex.SessionID, ex.Index, ex.Options)
+
+	fmt.Printf("%sQ: %s%s\n", ColorText, ex.Question, ColorReset)
+	fmt.Printf("%sOptions:%s\n", ColorInfo, ColorReset)
+	for i, option := range ex.Options {
+		fmt.Printf("   %s%s) %s%s\n", ColorText, optionLabel(i), option, ColorReset)
+	}
+	fmt.Println()
+}
+
+// Collect asks until the learner picks one of the options.
+func (multipleChoice) Collect(a *App, ex *practice) response {
+	last := optionLabel(len(ex.Options) - 1)
+	for {
+		given, typed := a.readAnswer(fmt.Sprintf("Your answer (a-%s or the option): ", last), ex.Language)
+		if a.timer.expired() {
+			return response{}
+		}
+		if chosen := a.pickOption(given, typed, ex.Options, ex.Language); chosen >= 0 {
+			return response{Given: ex.Options[chosen], Typed: typed, Choice: optionLabel(chosen)}
+		}
+		fmt.Println(ColorError.Render("Please choose one of the options a-" + last))
+	}
+}
+
+func (multipleChoice) Grade(a *App, ex *practice, r response) answer.Match {
+	correct := ex.AnswerOption(ex.Language)
+	match := answer.Match{Result: answer.Wrong, Expected: optionLabel(correct) + ") " + ex.Options[correct]}
+	if r.Choice == optionLabel(correct) {
+		match.Result = answer.Correct
+	}
+	return match
+}
+
+func (multipleChoice) Explain(a *App, ex *practice, match answer.Match) string {
+	return "The answer is: " + match.Expected
+}
+
+// pickOption finds the option meant by the input: its text first, then its
+// label, then its transliteration when that is shown. Labels are matched
+// against the keys typed, before any phonetic conversion. It returns -1
+// when nothing matches.
+func (a *App) pickOption(input, typed string, options []string, langInfo config.Language) int {
+	for i, option := range options {
+		if langInfo.NormalizeAnswer(input) == langInfo.NormalizeAnswer(option) {
+			return i
+		}
+	}
+
+	label := strings.TrimRight(strings.ToLower(strings.TrimSpace(typed)), ").")
+	for i := range options {
+		if label == optionLabel(i) {
+			return i
+		}
+	}
+
+	for i, option := range options {
+		if latin := a.transliterate(langInfo, option); latin != "" && translit.Fold(input) == translit.Fold(latin) {
+			return i
+		}
+	}
+	return -1
+}
+
+// shuffleOptions orders options the same way every time for a session's
+// exercise, so the answer isn't always in the same place.
+func shuffleOptions(sessionID string, index int, options []string) []string {
+	hash := fnv.New64a()
+	hash.Write([]byte(sessionID))
+	random := rand.New(rand.NewPCG(hash.Sum64(), uint64(index)))
+
+	shuffled := slices.Clone(options)
+	random.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+func optionLabel(index int) string {
+	return string(rune('a' + index))
+}
+
+// truthWords are the ways of saying true or false the learner may type, in
+// English and the built-in languages.
+var truthWords = map[string]bool{
+	"t": true, "true": true, "y": true, "yes": true, "right": true, "correct": true,
+	"f": false, "false": false, "n": false, "no": false, "wrong": false, "incorrect": false,
+	"да": true, "верно": true, "правда": true, "нет": false, "неверно": false, "неправда": false,
+	"ہاں": true, "صحیح": true, "درست": true, "سچ": true, "نہیں": false, "غلط": false, "جھوٹ": false,
+}
+
+// parseTruth reads a true or false answer, ignoring case and punctuation.
+func parseTruth(s string) (bool, bool) {
+	value, ok := truthWords[strings.ToLower(strings.Trim(strings.TrimSpace(s), ".!?"))]
+	return value, ok
+}
+
+// trueFalse asks whether a statement about the story is true.
+type trueFalse struct{}
+
+func (trueFalse) Valid(ex ai.Exercise) bool {
+	_, ok := parseTruth(ex.Answer)
+	return ok
+}
+
+func (trueFalse) Render(a *App, ex *practice) {
+	fmt.Printf("%sTrue or false?%s\n", ColorInfo, ColorReset)
+	fmt.Printf("%s%s%s\n", ColorText, ex.Question, ColorReset)
+	fmt.Println()
+}
+
+// Collect reads the keys typed, so t and f work with phonetic input on.
+func (trueFalse) Collect(a *App, ex *practice) response {
+	for {
+		given, typed := a.readAnswer("Your answer (t/f): ", ex.Language)
+		if a.timer.expired() {
+			return response{}
+		}
+		if value, ok := parseTruth(typed); ok {
+			return response{Given: fmt.Sprint(value), Typed: typed}
+		}
+		if value, ok := parseTruth(given); ok {
+			return response{Given: fmt.Sprint(value), Typed: typed}
+		}
+		fmt.Println(ColorError.Render("Please answer t (true) or f (false)"))
+	}
+}
+
+func (trueFalse) Grade(a *App, ex *practice, r response) answer.Match {
+	expected, _ := parseTruth(ex.Answer)
+	given, _ := parseTruth(r.Given)
+	match := answer.Match{Result: answer.Wrong, Expected: fmt.Sprint(expected)}
+	if given == expected {
+		match.Result = answer.Correct
+	}
+	return match
+}
+
+func (trueFalse) Explain(a *App, ex *practice, match answer.Match) string {
+	return "The statement is " + match.Expected
+}
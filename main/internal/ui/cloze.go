@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/history"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+const clozeGap = "____"
+
+type clozeItem struct {
+	vocab    ai.Vocabulary
+	sentence string
+}
+
+// runClozeDrill asks for each vocabulary word in its example sentence with
+// the word blanked out. Words whose example doesn't contain them are skipped.
+func (a *App) runClozeDrill(session *history.Session, langInfo config.Language) {
+	var items []clozeItem
+	for _, vocab := range session.Story.Vocabulary {
+		if sentence, ok := text.Blank(vocab.Example, vocab.Word, clozeGap); ok {
+			items = append(items, clozeItem{vocab: vocab, sentence: sentence})
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ColorPrimary.Render("📝 Vocabulary in Context"))
+	fmt.Println("──────────────────────────────────────────────────────────────────")
+
+	for i, item := range items {
+		fmt.Printf("\n%sSentence %d/%d:%s\n", ColorText, i+1, len(items), ColorReset)
+		fmt.Printf("%s%s%s\n", ColorAccent, item.sentence, ColorReset)
+		if item.vocab.ExampleTranslation != "" {
+			fmt.Printf("   %s🌍 %s%s\n", ColorInfo, item.vocab.ExampleTranslation, ColorReset)
+		} else {
+			fmt.Printf("   %s🌍 %s%s\n", ColorInfo, item.vocab.Translation, ColorReset)
+		}
+
+		fmt.Println()
+		userAnswer, _ := a.readAnswer("Missing word: ", langInfo)
+		match := a.checkAnswer(langInfo, userAnswer, item.vocab.Word)
+		session.Record(history.Answer{
+			Type:       "cloze",
+			Question:   item.sentence,
+			Expected:   item.vocab.Word,
+			Given:      userAnswer,
+			Correct:    match.Result.Accepted(),
+			Almost:     match.Result == answer.Almost,
+			Drill:      true,
+			AnsweredAt: time.Now(),
+		})
+		a.printMatch(match)
+	}
+
+	correct, total := session.DrillScore()
+	fmt.Printf("\n%s📊 Drill: %d/%d correct%s\n", ColorPrimary, correct, total, ColorReset)
+	fmt.Println("──────────────────────────────────────────────────────────────────")
+}
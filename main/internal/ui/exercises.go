@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// exerciseType is one kind of exercise: how it is shown, how the learner's
+// response is read, how it is graded and how the right answer is explained.
+type exerciseType interface {
+	Render(a *App, ex *practice)
+	Collect(a *App, ex *practice) response
+	Grade(a *App, ex *practice, r response) answer.Match
+	Explain(a *App, ex *practice, match answer.Match) string
+}
+
+// validator is implemented by types that need more than a question and an
+// answer, such as options. Exercises they can't handle are asked as free text.
+type validator interface {
+	Valid(ex ai.Exercise) bool
+}
+
+// practice is an exercise being asked in a session. Render may rearrange
+// it, for example by shuffling its options.
+type practice struct {
+	ai.Exercise
+	SessionID string
+	Index     int
+	Language  config.Language
+}
+
+// response is what the learner entered for an exercise.
+type response struct {
+	Given  string
+	Typed  string
+	Choice string
+}
+
+var exerciseTypes = map[string]exerciseType{}
+
+// exerciseAliases maps spellings models use for the registered types.
+var exerciseAliases = map[string]string{
+	"fill_in_blank":       "fill_blank",
+	"fill_in_the_blank":   "fill_blank",
+	"fill_in_the_blanks":  "fill_blank",
+	"cloze":               "fill_blank",
+	"true_or_false":       "true_false",
+	"multiple_choices":    "multiple_choice",
+	"match":               "matching",
+	"translate":           "translation",
+	"word_ordering":       "word_order",
+	"sentence_order":      "word_order",
+	"sentence_ordering":   "word_order",
+	"reorder":             "word_order",
+	"sentence_reordering": "word_order",
+}
+
+func registerExerciseType(name string, kind exerciseType) {
+	exerciseTypes[name] = kind
+}
+
+func init() {
+	registerExerciseType("multiple_choice", multipleChoice{})
+	registerExerciseType("true_false", trueFalse{})
+	registerExerciseType("fill_blank", fillBlank{})
+	registerExerciseType("matching", matching{})
+	registerExerciseType("translation", translation{})
+	registerExerciseType("word_order", wordOrder{})
+}
+
+// exerciseTypeName normalizes names like "Fill-in-the-blank" or
+// "true/false" to the registered ones.
+func exerciseTypeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_", "/", "_").Replace(name)
+	if alias, ok := exerciseAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// lookupExerciseType returns the type handling an exercise, falling back to
+// a free-text question for unknown types and exercises a type can't handle.
+func lookupExerciseType(ex ai.Exercise) exerciseType {
+	kind, ok := exerciseTypes[exerciseTypeName(ex.Type)]
+	if !ok {
+		return freeText{}
+	}
+	if v, ok := kind.(validator); ok && !v.Valid(ex) {
+		return freeText{}
+	}
+	return kind
+}
+
+// freeText asks for a typed answer. It handles every unknown type.
+type freeText struct{}
+
+func (freeText) Render(a *App, ex *practice) {
+	fmt.Printf("%sQ: %s%s\n", ColorText, ex.Question, ColorReset)
+	fmt.Println()
+}
+
+func (freeText) Collect(a *App, ex *practice) response {
+	given, typed := a.readAnswer("Your answer: ", ex.Language)
+	return response{Given: given, Typed: typed}
+}
+
+func (freeText) Grade(a *App, ex *practice, r response) answer.Match {
+	return a.gradeFreeText(ex, r.Given, a.checkAnswer(ex.Language, r.Given, ex.Accepted()...))
+}
+
+func (freeText) Explain(a *App, ex *practice, match answer.Match) string {
+	return feedback("The answer is: "+match.Expected, match)
+}
+
+var blank = regexp.MustCompile(`_{2,}|\.{3,}|…`)
+
+// fillBlank asks for the word missing from a sentence marked with ___.
+type fillBlank struct{}
+
+func (fillBlank) Render(a *App, ex *practice) {
+	question := blank.ReplaceAllStringFunc(ex.Question, func(gap string) string {
+		return ColorAccent.Bold(true).Render("____")
+	})
+	fmt.Printf("%sFill in the blank:%s\n", ColorInfo, ColorReset)
+	fmt.Printf("%s%s%s\n", ColorText, question, ColorReset)
+	fmt.Println()
+}
+
+func (fillBlank) Collect(a *App, ex *practice) response {
+	given, typed := a.readAnswer("Missing word: ", ex.Language)
+	return response{Given: given, Typed: typed}
+}
+
+func (fillBlank) Grade(a *App, ex *practice, r response) answer.Match {
+	return a.checkAnswer(ex.Language, r.Given, ex.Accepted()...)
+}
+
+func (fillBlank) Explain(a *App, ex *practice, match answer.Match) string {
+	if loc := blank.FindStringIndex(ex.Question); loc != nil {
+		return "The sentence is: " + ex.Question[:loc[0]] + match.Expected + ex.Question[loc[1]:]
+	}
+	return "The missing word is: " + match.Expected
+}
+
+// translation asks for a sentence in another language. Word order and
+// punctuation inside the sentence are compared word by word.
+type translation struct{}
+
+func (translation) Render(a *App, ex *practice) {
+	fmt.Printf("%sTranslate:%s\n", ColorInfo, ColorReset)
+	fmt.Printf("%s%s%s\n", ColorText, ex.Question, ColorReset)
+	fmt.Println()
+}
+
+func (translation) Collect(a *App, ex *practice) response {
+	given, typed := a.readAnswer("Translation: ", ex.Language)
+	return response{Given: given, Typed: typed}
+}
+
+func (translation) Grade(a *App, ex *practice, r response) answer.Match {
+	var accepted []string
+	for _, sentence := range ex.Accepted() {
+		accepted = append(accepted, strings.Join(text.Words(sentence), " "))
+	}
+	match := a.checkAnswer(ex.Language, strings.Join(text.Words(r.Given), " "), accepted...)
+	match.Expected = ex.Answer
+	return a.gradeFreeText(ex, r.Given, match)
+}
+
+func (translation) Explain(a *App, ex *practice, match answer.Match) string {
+	return feedback("A good translation: "+ex.Answer, match)
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+)
+
+// grammarNote summarises part of speech, dictionary form and grammatical
+// features on one line, e.g. "verb · пойти · aspect: perfective, aspect pair: идти".
+// Features listed in order come first, any others follow alphabetically.
+func grammarNote(word, partOfSpeech, lemma string, features map[string]string, order []string) string {
+	var parts []string
+	if partOfSpeech != "" {
+		parts = append(parts, partOfSpeech)
+	}
+	if lemma != "" && !strings.EqualFold(lemma, word) {
+		parts = append(parts, lemma)
+	}
+
+	seen := map[string]bool{}
+	var keys []string
+	for _, key := range order {
+		if features[key] != "" {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	var rest []string
+	for key, value := range features {
+		if !seen[key] && value != "" {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+
+	var described []string
+	for _, key := range append(keys, rest...) {
+		described = append(described, strings.ReplaceAll(key, "_", " ")+": "+features[key])
+	}
+	if len(described) > 0 {
+		parts = append(parts, strings.Join(described, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func pronunciation(ipa string) string {
+	if ipa == "" {
+		return ""
+	}
+	return " [" + strings.Trim(ipa, "[]/") + "]"
+}
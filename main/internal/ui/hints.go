@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// hintRequest is typed instead of an answer to get the next hint.
+const hintRequest = "?"
+
+// hinter hands out an exercise's hints one at a time, from vague to
+// revealing.
+type hinter struct {
+	hints []string
+	used  int
+}
+
+// newHinter uses the hints the model wrote for the exercise, or builds
+// them from the answer and the story: the first letter, the length, the
+// story sentence containing the answer and its translation.
+func newHinter(story *ai.StoryResponse, ex *practice) *hinter {
+	if len(ex.Hints) > 0 {
+		return &hinter{hints: ex.Hints}
+	}
+
+	var hints []string
+	switch exerciseTypeName(ex.Type) {
+	case "true_false", "matching":
+	default:
+		if words := text.Words(ex.Answer); len(words) > 1 {
+			hints = append(hints,
+				fmt.Sprintf("Starts with «%s»", words[0]),
+				fmt.Sprintf("%d words", len(words)))
+		} else if runes := []rune(ex.Answer); len(runes) > 0 {
+			hints = append(hints,
+				fmt.Sprintf("Starts with «%c»", runes[0]),
+				fmt.Sprintf("%d letters", len(runes)))
+		}
+	}
+
+	if i := story.SentenceWith(ex.Language, ex.Answer); i >= 0 {
+		hints = append(hints, "From the story: "+story.Sentences(ex.Language)[i])
+		if translations := story.TranslatedSentences(ex.Language); translations != nil {
+			hints = append(hints, "In English: "+translations[i])
+		}
+	}
+	return &hinter{hints: hints}
+}
+
+// next returns the next hint, or false when they have run out.
+func (h *hinter) next() (string, bool) {
+	if h.used == len(h.hints) {
+		return "", false
+	}
+	h.used++
+	return h.hints[h.used-1], true
+}
+
+// showHint prints the next hint for the exercise being answered.
+func (a *App) showHint() {
+	if a.hint == nil {
+		return
+	}
+	hint, ok := a.hint.next()
+	if !ok && len(a.hint.hints) == 0 {
+		a.printWarning("No hints for this exercise")
+		return
+	}
+	if !ok {
+		a.printWarning("No more hints for this exercise")
+		return
+	}
+	fmt.Printf("%s💡 Hint %d/%d: %s%s\n", ColorInfo, a.hint.used, len(a.hint.hints), hint, ColorReset)
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/history"
+)
+
+// historyPageSize caps how many past sessions are listed at once.
+const historyPageSize = 20
+
+func (a *App) showHistory() error {
+	sessions, err := a.history.List()
+	if err != nil {
+		return err
+	}
+
+	pages := (len(sessions) + historyPageSize - 1) / historyPageSize
+	page := 0
+	for {
+		a.printHeader()
+		fmt.Println(ColorPrimary.Render("📜 History"))
+		fmt.Println("══════════════════════════════════════════════════════════════════")
+		fmt.Println()
+
+		if len(sessions) == 0 {
+			a.printWarning("No sessions yet. Start a new learning session first!")
+			return nil
+		}
+
+		first := page * historyPageSize
+		last := min(first+historyPageSize, len(sessions))
+		for i := first; i < last; i++ {
+			session := sessions[i]
+			langInfo := config.Languages[session.Language]
+			fmt.Printf("   %s%2d.%s %s  %s  %s%s%s  %s (%d/%d)\n",
+				ColorText, i+1, ColorReset,
+				session.StartedAt.Format("2006-01-02 15:04"), langInfo.Display,
+				ColorAccent, session.Level, ColorReset,
+				session.Topic, session.Score, session.Total)
+		}
+		fmt.Println()
+
+		prompt := fmt.Sprintf("Open session (%d-%d", first+1, last)
+		if pages > 1 {
+			fmt.Printf("%sPage %d of %d%s\n", ColorText, page+1, pages, ColorReset)
+			if page+1 < pages {
+				prompt += ", n for next"
+			}
+			if page > 0 {
+				prompt += ", p for previous"
+			}
+		}
+		input := strings.ToLower(a.readLine(prompt + ", q to go back): "))
+
+		switch input {
+		case "q", "quit", "":
+			return nil
+		case "n", "next":
+			page = min(page+1, pages-1)
+			continue
+		case "p", "prev", "previous":
+			page = max(page-1, 0)
+			continue
+		}
+
+		choice, err := strconv.Atoi(input)
+		if err != nil || choice < 1 || choice > len(sessions) {
+			continue
+		}
+		return a.reopenSession(sessions[choice-1])
+	}
+}
+
+func (a *App) reopenSession(session *history.Session) error {
+	a.printStory(session, "📜 "+session.StartedAt.Format("2006-01-02 15:04"))
+
+	if !a.currentConfig.AutoTranslate {
+		a.revealTranslation(session.Story, config.Languages[session.Language])
+	}
+
+	if len(session.Answers) > 0 {
+		fmt.Printf("%s📝 Your Answers:%s\n", ColorPrimary, ColorReset)
+		for _, answer := range session.Answers {
+			if answer.Choice != "" {
+				answer.Given = answer.Choice + ") " + answer.Given
+			}
+			if answer.TimedOut {
+				answer.Given = "⏰"
+			} else if answer.ResponseMS > 0 {
+				answer.Given += fmt.Sprintf(" (%.1fs)", float64(answer.ResponseMS)/1000)
+			}
+			if answer.Hints > 0 {
+				answer.Given += fmt.Sprintf(" 💡%d", answer.Hints)
+			}
+			if answer.Retry > 0 {
+				answer.Question = fmt.Sprintf("🔁%d %s", answer.Retry, answer.Question)
+			}
+			if answer.Drill {
+				answer.Question = "📝 " + answer.Question
+			}
+			if answer.Almost {
+				fmt.Printf("   %s🟡 %s → %s (answer: %s)%s\n", ColorWarning, answer.Question, answer.Given, answer.Expected, ColorReset)
+			} else if answer.Correct {
+				fmt.Printf("   %s✅ %s → %s%s\n", ColorSuccess, answer.Question, answer.Given, ColorReset)
+			} else {
+				fmt.Printf("   %s❌ %s → %s (answer: %s)%s\n", ColorError, answer.Question, answer.Given, answer.Expected, ColorReset)
+			}
+		}
+		a.printScore(session)
+		if eventual := session.EventualScore(); eventual > session.Score {
+			fmt.Printf("%s🔁 After retries: %d/%d correct%s\n", ColorPrimary, eventual, session.Total, ColorReset)
+		}
+		if correct, total := session.DrillScore(); total > 0 {
+			fmt.Printf("%s📝 Drill: %d/%d correct%s\n", ColorPrimary, correct, total, ColorReset)
+		}
+		fmt.Println()
+	}
+
+	input := a.readLine("Practice this story again? (y/N): ")
+	if !strings.EqualFold(input, "y") && !strings.EqualFold(input, "yes") {
+		return nil
+	}
+
+	retry := history.NewSession(session.Language, session.Level, session.Topic)
+	retry.Story = session.Story
+	return a.runSession(retry)
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package ui
+
+import "time"
+
+// waitForKey can't wait for input on this platform, so reads block and
+// time limits are only checked once an answer is in.
+func waitForKey(fd int, timeout time.Duration) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package ui
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// waitForKey waits up to timeout for input on fd and reports whether there
+// is some to read.
+func waitForKey(fd int, timeout time.Duration) (bool, error) {
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	for {
+		n, err := unix.Poll(fds, int(timeout.Milliseconds()))
+		if err == unix.EINTR {
+			continue
+		}
+		return n > 0, err
+	}
+}
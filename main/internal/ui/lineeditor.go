@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/translit"
+	"golang.org/x/term"
+)
+
+// lineEditor reads a line with the terminal in raw mode so that the text
+// can be shown converted while it is typed. Tab switches conversion off
+// and on, for answers that belong in Latin letters. With a deadline, the
+// time left is counted down next to the line.
+type lineEditor struct {
+	prompt   string
+	convert  func(string) string // nil for no conversion
+	deadline time.Time
+}
+
+// read returns the converted line along with what was actually typed.
+func (e *lineEditor) read() (string, string, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", "", err
+	}
+	defer term.Restore(fd, state)
+
+	var typed []rune
+	var pending []byte
+	converting := true
+	key := make([]byte, 1)
+
+	line := func() string {
+		if converting && e.convert != nil {
+			return e.convert(string(typed))
+		}
+		return string(typed)
+	}
+
+	e.draw(line(), string(typed), converting)
+	for {
+		if !e.deadline.IsZero() {
+			left := time.Until(e.deadline)
+			if left <= 0 {
+				fmt.Print("\r\n")
+				return line(), string(typed), errTimeout
+			}
+			// Wake up every second to update the countdown
+			ready, err := waitForKey(fd, min(left, time.Second))
+			if err != nil {
+				fmt.Print("\r\n")
+				return line(), string(typed), err
+			}
+			if !ready {
+				e.draw(line(), string(typed), converting)
+				continue
+			}
+		}
+		if _, err := os.Stdin.Read(key); err != nil {
+			fmt.Print("\r\n")
+			return line(), string(typed), err
+		}
+
+		switch c := key[0]; {
+		case c == '\r' || c == '\n':
+			e.draw(line(), "", converting)
+			fmt.Print("\r\n")
+			return line(), string(typed), nil
+		case c == 3: // Ctrl-C
+			term.Restore(fd, state)
+			fmt.Println()
+			os.Exit(130)
+		case c == 4: // Ctrl-D
+			if len(typed) == 0 {
+				fmt.Print("\r\n")
+				return "", "", io.EOF
+			}
+		case c == '\t':
+			converting = !converting
+		case c == 127 || c == 8: // Backspace
+			if len(typed) > 0 {
+				typed = typed[:len(typed)-1]
+			}
+		case c == 21: // Ctrl-U
+			typed = typed[:0]
+		case c == 23: // Ctrl-W
+			for len(typed) > 0 && unicode.IsSpace(typed[len(typed)-1]) {
+				typed = typed[:len(typed)-1]
+			}
+			for len(typed) > 0 && !unicode.IsSpace(typed[len(typed)-1]) {
+				typed = typed[:len(typed)-1]
+			}
+		case c == 27:
+			skipEscapeSequence()
+		case c < 32:
+		default:
+			pending = append(pending, c)
+			if utf8.FullRune(pending) {
+				r, _ := utf8.DecodeRune(pending)
+				typed = append(typed, r)
+				pending = pending[:0]
+			}
+		}
+		e.draw(line(), string(typed), converting)
+	}
+}
+
+// draw redraws the line with the typed Latin text as a hint and the
+// countdown after the cursor.
+func (e *lineEditor) draw(line, typed string, converting bool) {
+	fmt.Print("\r\033[K" + ColorInfo.Render(e.prompt) + line + "\0337")
+	if typed != "" && e.convert != nil {
+		mode := "Tab: Latin"
+		if !converting {
+			mode = "Tab: convert"
+		}
+		fmt.Print(ColorText.Render("   ⌨️ " + typed + " · " + mode))
+	}
+	if !e.deadline.IsZero() {
+		fmt.Print("   " + countdown(e.deadline))
+	}
+	fmt.Print("\0338")
+}
+
+// skipEscapeSequence drops the rest of an arrow or function key sequence.
+func skipEscapeSequence() {
+	key := make([]byte, 1)
+	if _, err := os.Stdin.Read(key); err != nil || (key[0] != '[' && key[0] != 'O') {
+		return
+	}
+	for {
+		if _, err := os.Stdin.Read(key); err != nil || (key[0] >= 0x40 && key[0] <= 0x7e) {
+			return
+		}
+	}
+}
+
+// readAnswer reads an answer in the language, showing the next hint instead
+// while an exercise with hints is being answered and ? is typed.
+func (a *App) readAnswer(prompt string, langInfo config.Language) (string, string) {
+	for {
+		answer, typed := a.readAnswerOnce(prompt, langInfo)
+		if a.hint == nil || typed != hintRequest {
+			return answer, typed
+		}
+		a.showHint()
+	}
+}
+
+// readAnswerOnce reads an answer in the language. With phonetic input on,
+// Latin typing is converted into the language's script as it is typed.
+// In a timed quiz the answer is cut off at the deadline and is empty.
+// It returns the answer and the text as typed.
+func (a *App) readAnswerOnce(prompt string, langInfo config.Language) (string, string) {
+	phonetic := a.currentConfig.PhoneticInput && len(langInfo.Phonetic) > 0
+	timed := a.timer != nil && !a.timer.deadline.IsZero()
+	if (!phonetic && !timed) || !term.IsTerminal(int(os.Stdin.Fd())) {
+		answer := a.readLine(prompt)
+		return answer, answer
+	}
+
+	editor := lineEditor{prompt: prompt}
+	if phonetic {
+		editor.convert = func(typed string) string {
+			return translit.Phonetic(typed, langInfo.Phonetic)
+		}
+	}
+	if timed {
+		editor.deadline = a.timer.deadline
+	}
+	answer, typed, err := editor.read()
+	if err == errTimeout {
+		return "", ""
+	}
+	if err != nil && err != io.EOF {
+		answer = a.readLine(prompt)
+		return answer, answer
+	}
+	return strings.TrimSpace(answer), strings.TrimSpace(typed)
+}
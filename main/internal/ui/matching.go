@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+)
+
+// matching pairs words with their translations. Options hold the pairs as
+// "word = translation"; the translations are shuffled and labelled.
+type matching struct{}
+
+type pair struct {
+	left, right string
+}
+
+var pairSeparator = regexp.MustCompile(`\s*(=|→|->|\s[-–—:]\s)\s*`)
+
+func parsePairs(options []string) []pair {
+	var pairs []pair
+	for _, option := range options {
+		parts := pairSeparator.Split(option, 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil
+		}
+		pairs = append(pairs, pair{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])})
+	}
+	return pairs
+}
+
+// rights returns the right-hand sides in the order they are shown.
+func (matching) rights(ex *practice) []string {
+	var rights []string
+	for _, p := range parsePairs(ex.Options) {
+		rights = append(rights, p.right)
+	}
+	return shuffleOptions(ex.SessionID, ex.Index, rights)
+}
+
+// key returns the correct label for each left-hand side, like "1b 2a".
+func (m matching) key(ex *practice) []string {
+	rights := m.rights(ex)
+	var key []string
+	for i, p := range parsePairs(ex.Options) {
+		key = append(key, strconv.Itoa(i+1)+optionLabel(slices.Index(rights, p.right)))
+	}
+	return key
+}
+
+func (matching) Valid(ex ai.Exercise) bool {
+	pairs := parsePairs(ex.Options)
+	return len(pairs) >= 2 && len(pairs) <= 26
+}
+
+func (m matching) Render(a *App, ex *practice) {
+	fmt.Printf("%sMatch the pairs:%s %s\n", ColorInfo, ColorReset, ex.Question)
+	rights := m.rights(ex)
+	for i, p := range parsePairs(ex.Options) {
+		fmt.Printf("   %s%d. %-20s %s) %s%s\n", ColorText, i+1, p.left, optionLabel(i), rights[i], ColorReset)
+	}
+	fmt.Println()
+}
+
+var matchPattern = regexp.MustCompile(`(\d+)\s*[-=:.)]?\s*([a-z])`)
+
+// Collect accepts "1b 2a 3c" or just the letters in order, "bac".
+func (matching) Collect(a *App, ex *practice) response {
+	count := len(parsePairs(ex.Options))
+	for {
+		_, typed := a.readAnswer("Your pairs (e.g. 1b 2a): ", ex.Language)
+		if a.timer.expired() {
+			return response{}
+		}
+		input := strings.ToLower(typed)
+
+		chosen := make([]string, count)
+		if matchPattern.MatchString(input) {
+			for _, m := range matchPattern.FindAllStringSubmatch(input, -1) {
+				if n, _ := strconv.Atoi(m[1]); n >= 1 && n <= count {
+					chosen[n-1] = m[2]
+				}
+			}
+		} else if letters := strings.Join(strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' }), ""); len(letters) == count {
+			for i, r := range letters {
+				chosen[i] = string(r)
+			}
+		}
+
+		if !slices.Contains(chosen, "") {
+			var pairs []string
+			for i, label := range chosen {
+				pairs = append(pairs, strconv.Itoa(i+1)+label)
+			}
+			return response{Given: strings.Join(pairs, " "), Typed: typed}
+		}
+		fmt.Println(ColorError.Render(fmt.Sprintf("Please give a letter for each of the %d words", count)))
+	}
+}
+
+func (m matching) Grade(a *App, ex *practice, r response) answer.Match {
+	key := strings.Join(m.key(ex), " ")
+	match := answer.Match{Result: answer.Wrong, Expected: key}
+	if r.Given == key {
+		match.Result = answer.Correct
+	}
+	return match
+}
+
+func (matching) Explain(a *App, ex *practice, match answer.Match) string {
+	var pairs []string
+	for _, p := range parsePairs(ex.Options) {
+		pairs = append(pairs, p.left+" = "+p.right)
+	}
+	return "The pairs are: " + match.Expected + " (" + strings.Join(pairs, ", ") + ")"
+}
+
+// wordOrder asks for a sentence to be put back together from its words.
+// Options may give the words; otherwise the answer's words are shuffled.
+type wordOrder struct{}
+
+func (wordOrder) tiles(ex *practice) []string {
+	if len(ex.Options) > 1 {
+		return ex.Options
+	}
+	words := text.Words(ex.Answer)
+	// Avoid showing the sentence already in order
+	for attempt := 0; attempt < 5; attempt++ {
+		tiles := shuffleOptions(ex.SessionID, ex.Index*10+attempt, words)
+		if !slices.Equal(tiles, words) {
+			return tiles
+		}
+	}
+	return words
+}
+
+func (wordOrder) Valid(ex ai.Exercise) bool {
+	return len(text.Words(ex.Answer)) >= 2
+}
+
+func (w wordOrder) Render(a *App, ex *practice) {
+	fmt.Printf("%sPut the words in order:%s %s\n", ColorInfo, ColorReset, ex.Question)
+	var tiles []string
+	for i, word := range w.tiles(ex) {
+		tiles = append(tiles, fmt.Sprintf("%d) %s", i+1, word))
+	}
+	fmt.Printf("   %s%s%s\n", ColorAccent, strings.Join(tiles, "   "), ColorReset)
+	fmt.Println()
+}
+
+var tileNumbers = regexp.MustCompile(`^[\d\s,]+$`)
+
+// Collect accepts the sentence itself or the tile numbers in order.
+func (w wordOrder) Collect(a *App, ex *practice) response {
+	given, typed := a.readAnswer("Sentence (or tile numbers): ", ex.Language)
+	if !tileNumbers.MatchString(typed) {
+		return response{Given: given, Typed: typed}
+	}
+
+	tiles := w.tiles(ex)
+	var words []string
+	for _, field := range strings.FieldsFunc(typed, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if n, err := strconv.Atoi(field); err == nil && n >= 1 && n <= len(tiles) {
+			words = append(words, tiles[n-1])
+		}
+	}
+	return response{Given: strings.Join(words, " "), Typed: typed}
+}
+
+func (wordOrder) Grade(a *App, ex *practice, r response) answer.Match {
+	var accepted []string
+	for _, sentence := range ex.Accepted() {
+		accepted = append(accepted, strings.Join(text.Words(sentence), " "))
+	}
+	match := a.checkAnswer(ex.Language, strings.Join(text.Words(r.Given), " "), accepted...)
+	match.Expected = ex.Answer
+	return match
+}
+
+func (wordOrder) Explain(a *App, ex *practice, match answer.Match) string {
+	return "The sentence is: " + ex.Answer
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
@@ -15,14 +14,7 @@ func (a *App) showLanguageMenu() {
 	fmt.Println("──────────────────────────────────────────────────────────────────")
 	fmt.Println()
 
-	// Get sorted language keys for consistent ordering
-	var languages []string
-	for lang := range config.Languages {
-		languages = append(languages, lang)
-	}
-	sort.Strings(languages)
-
-	for i, lang := range languages {
+	for i, lang := range config.LanguageKeys() {
 		language := config.Languages[lang]
 		fmt.Printf("   %s%d.%s %s\n", ColorText, i+1, ColorReset, language.Display)
 	}
@@ -34,17 +26,9 @@ func (a *App) showLevelMenu() {
 	fmt.Println("──────────────────────────────────────────────────────────────────")
 	fmt.Println()
 
-	// Get sorted level keys for consistent ordering
-	var levels []string
-	for level := range config.Levels {
-		levels = append(levels, level)
-	}
-	sort.Strings(levels)
-
-	for i, level := range levels {
+	for i, level := range config.LevelCodes {
 		levelInfo := config.Levels[level]
-		displayLevel := strings.Title(level)
-		fmt.Printf("   %s%d.%s %s (%s)\n", ColorText, i+1, ColorReset, displayLevel, levelInfo.Description)
+		fmt.Printf("   %s%d.%s %s %s (%s)\n", ColorText, i+1, ColorReset, levelInfo.Code, levelInfo.Name, levelInfo.Description)
 	}
 	fmt.Println()
 }
@@ -55,10 +39,13 @@ func (a *App) showMainMenu() {
 	fmt.Println("══════════════════════════════════════════════════════════════════")
 	fmt.Println()
 	fmt.Printf("   %s1. 🆕 New Learning Session%s\n", ColorSuccess, ColorReset)
-	fmt.Printf("   %s2. 🌍 Change Language%s\n", ColorInfo, ColorReset)
-	fmt.Printf("   %s3. 📊 Change Level%s\n", ColorWarning, ColorReset)
-	fmt.Printf("   %s4. ⚙️ Settings%s\n", ColorText, ColorReset)
-	fmt.Printf("   %s5. 🚪 Exit%s\n", ColorError, ColorReset)
+	fmt.Printf("   %s2. 🔁 Review Vocabulary%s%s\n", ColorSuccess, a.dueCardsLabel(), ColorReset)
+	fmt.Printf("   %s3. 📚 Vocabulary Notebook%s\n", ColorSuccess, ColorReset)
+	fmt.Printf("   %s4. 🌍 Change Language%s\n", ColorInfo, ColorReset)
+	fmt.Printf("   %s5. 📊 Change Level%s\n", ColorWarning, ColorReset)
+	fmt.Printf("   %s6. ⚙️ Settings%s\n", ColorText, ColorReset)
+	fmt.Printf("   %s7. 📜 History%s\n", ColorAccent, ColorReset)
+	fmt.Printf("   %s8. 🚪 Exit%s\n", ColorError, ColorReset)
 	fmt.Println()
 }
 
@@ -66,13 +53,12 @@ func (a *App) selectLanguage() error {
 	a.printHeader()
 	a.showLanguageMenu()
 
-	choice, quit := a.getUserChoice("Choose language (1-3): ", 1, 3)
+	// Map choice to language
+	languages := config.LanguageKeys()
+	choice, quit := a.getUserChoice(fmt.Sprintf("Choose language (1-%d): ", len(languages)), 1, len(languages))
 	if quit {
 		return nil
 	}
-
-	// Map choice to language
-	languages := []string{"russian", "urdu", "english"}
 	selectedLang := languages[choice-1]
 
 	a.currentConfig.Language = selectedLang
@@ -89,13 +75,12 @@ func (a *App) selectLevel() error {
 	a.printHeader()
 	a.showLevelMenu()
 
-	choice, quit := a.getUserChoice("Choose level (1-3): ", 1, 3)
+	// Map choice to level
+	levels := config.LevelCodes
+	choice, quit := a.getUserChoice(fmt.Sprintf("Choose level (1-%d): ", len(levels)), 1, len(levels))
 	if quit {
 		return nil
 	}
-
-	// Map choice to level
-	levels := []string{"beginner", "intermediate", "advanced"}
 	selectedLevel := levels[choice-1]
 
 	a.currentConfig.Level = selectedLevel
@@ -103,7 +88,7 @@ func (a *App) selectLevel() error {
 		return err
 	}
 
-	a.printSuccess("Level set to: " + selectedLevel)
+	a.printSuccess("Level set to: " + selectedLevel + " " + config.Levels[selectedLevel].Name)
 	return nil
 }
 
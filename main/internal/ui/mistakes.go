@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+)
+
+// typedAnswer reports whether an exercise type takes a typed answer that
+// can be compared with the expected text character by character. Choices,
+// pairs and plugin answers can't.
+func typedAnswer(kind exerciseType) bool {
+	switch kind.(type) {
+	case freeText, fillBlank, translation, wordOrder:
+		return true
+	}
+	return false
+}
+
+// reviewMistake shows how a wrong typed answer differs from the expected
+// one and offers an AI explanation of the mistake.
+func (a *App) reviewMistake(story *ai.StoryResponse, ex *practice, given string, match answer.Match) {
+	if strings.TrimSpace(given) == "" || match.Expected == "" {
+		return
+	}
+
+	changes := answer.Diff(match.Expected, given)
+	fmt.Printf("   %sExpected:%s %s\n", ColorInfo, ColorReset, renderChanges(changes, answer.Missing))
+	fmt.Printf("   %sYours:%s    %s\n", ColorInfo, ColorReset, renderChanges(changes, answer.Extra))
+
+	choice := a.readLine("💡 Type e to explain this mistake, or press Enter to continue: ")
+	if !strings.EqualFold(choice, "e") {
+		return
+	}
+
+	sentence := ""
+	if i := story.SentenceWith(ex.Language, match.Expected); i >= 0 {
+		sentence = story.Sentences(ex.Language)[i]
+	}
+	fmt.Printf("%s🤖 Asking for an explanation...%s\n", ColorInfo, ColorReset)
+	explanation, err := a.aiClient.ExplainMistake(ex.Language, a.currentConfig.NativeLanguage, sentence, ex.Question, match.Expected, given)
+	if err != nil {
+		a.printWarning("No explanation available: " + err.Error())
+		return
+	}
+	fmt.Printf("%s💬 %s%s\n", ColorText, explanation, ColorReset)
+}
+
+// renderChanges renders one side of a diff: the common text with either
+// the missing or the extra characters marked.
+func renderChanges(changes []answer.Change, side answer.Op) string {
+	var b strings.Builder
+	for _, change := range changes {
+		switch change.Op {
+		case answer.Same:
+			b.WriteString(ColorText.Render(change.Text))
+		case side:
+			if side == answer.Missing {
+				b.WriteString(ColorSuccess.Underline(true).Render(change.Text))
+			} else {
+				b.WriteString(ColorError.Strikethrough(true).Render(change.Text))
+			}
+		}
+	}
+	return b.String()
+}
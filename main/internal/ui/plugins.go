@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/plugin"
+)
+
+// loadPlugins finds the exercise plugins and registers the exercise types
+// they grade. Broken plugins are reported and skipped.
+func (a *App) loadPlugins() {
+	plugins, errs := plugin.Discover(a.configManager.PluginsDir())
+	for _, err := range errs {
+		a.printWarning("Plugin skipped: " + err.Error())
+	}
+
+	for _, p := range plugins {
+		for _, name := range p.ExerciseTypes {
+			name = exerciseTypeName(name)
+			if _, taken := exerciseTypes[name]; taken {
+				a.printWarning(fmt.Sprintf("Plugin %s: exercise type %q is already handled", p.Name, name))
+				continue
+			}
+			registerExerciseType(name, pluginExercise{plugin: p})
+		}
+	}
+	a.plugins = plugins
+}
+
+// addPluginExercises appends the exercises each plugin generates for the
+// story. A failing plugin only costs its own exercises.
+func (a *App) addPluginExercises(story *ai.StoryResponse) {
+	for _, p := range a.plugins {
+		exercises, err := p.Generate(story, a.currentConfig.Language, a.currentConfig.Level)
+		if err != nil {
+			a.printWarning(err.Error())
+			continue
+		}
+		story.Exercises = append(story.Exercises, exercises...)
+	}
+}
+
+// pluginExercise is an exercise type graded by a plugin. Answers are graded
+// locally when the plugin fails.
+type pluginExercise struct {
+	plugin *plugin.Plugin
+}
+
+func (pluginExercise) Render(a *App, ex *practice) {
+	fmt.Printf("%sQ: %s%s\n", ColorText, ex.Question, ColorReset)
+	if len(ex.Options) > 0 {
+		fmt.Printf("%sOptions:%s\n", ColorInfo, ColorReset)
+		for _, option := range ex.Options {
+			fmt.Printf("   %s%s%s\n", ColorText, option, ColorReset)
+		}
+	}
+	fmt.Println()
+}
+
+func (pluginExercise) Collect(a *App, ex *practice) response {
+	given, typed := a.readAnswer("Your answer: ", ex.Language)
+	return response{Given: given, Typed: typed}
+}
+
+func (k pluginExercise) Grade(a *App, ex *practice, r response) answer.Match {
+	resp, err := k.plugin.Grade(ex.Exercise, r.Given, ex.Language.Key)
+	if err != nil {
+		a.printWarning(err.Error())
+		return a.checkAnswer(ex.Language, r.Given, ex.Accepted()...)
+	}
+
+	match := answer.Match{Result: answer.Wrong, Expected: ex.Answer, Explanation: resp.Explanation}
+	switch {
+	case resp.Correct:
+		match.Result = answer.Correct
+	case resp.Almost:
+		match.Result = answer.Almost
+	}
+	return match
+}
+
+func (pluginExercise) Explain(a *App, ex *practice, match answer.Match) string {
+	if match.Explanation != "" {
+		return match.Explanation
+	}
+	return "The answer is: " + match.Expected
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/history"
+)
+
+// storyRecord links a running session to its rows in the progress database.
+// A nil record stores nothing, so sessions still work without the database.
+type storyRecord struct {
+	store       *db.DB
+	sessionID   string
+	exerciseIDs []int64
+}
+
+func (a *App) recordStory(session *history.Session) *storyRecord {
+	if a.store == nil {
+		return nil
+	}
+
+	if err := a.store.StartSession(a.userID, session.ID, session.Language, session.Level, session.StartedAt); err != nil {
+		a.printWarning("Progress not saved: " + err.Error())
+		return nil
+	}
+
+	_, exerciseIDs, err := a.store.SaveStory(a.userID, db.StoryMeta{
+		SessionID: session.ID,
+		Language:  session.Language,
+		Level:     session.Level,
+		Topic:     session.Topic,
+		Model:     a.aiClient.Model(),
+		CreatedAt: session.StartedAt,
+	}, session.Story)
+	if err != nil {
+		a.printWarning("Progress not saved: " + err.Error())
+		return nil
+	}
+
+	return &storyRecord{
+		store:       a.store,
+		sessionID:   session.ID,
+		exerciseIDs: exerciseIDs,
+	}
+}
+
+func (r *storyRecord) answer(index int, answer history.Answer) error {
+	if r == nil || index >= len(r.exerciseIDs) {
+		return nil
+	}
+	return r.store.RecordAnswer(r.exerciseIDs[index], answer.Given, answer.Correct, answer.Hints, answer.AnsweredAt)
+}
+
+func (r *storyRecord) finish(session *history.Session) error {
+	if r == nil {
+		return nil
+	}
+	return r.store.EndSession(r.sessionID, time.Now(), session.Total, session.Points())
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/history"
+)
+
+// masteryTarget is how often each exercise must be answered correctly in
+// mastery mode.
+const masteryTarget = 2
+
+// retryExercises offers another round of the exercises missed on the first
+// try. In mastery mode rounds go on until every exercise has been answered
+// correctly twice, first try included, or the learner stops.
+func (a *App) retryExercises(session *history.Session, langInfo config.Language, results []answer.Result) {
+	mastery := a.currentConfig.MasteryMode
+	target := 1
+	if mastery {
+		target = masteryTarget
+	}
+
+	correct := make([]int, len(results))
+	solved := make([]bool, len(results))
+	for i, result := range results {
+		if result.Accepted() {
+			correct[i], solved[i] = 1, true
+		}
+	}
+
+	rounds := 0
+	for round := 1; ; round++ {
+		var pending []int
+		for i, n := range correct {
+			if n < target {
+				pending = append(pending, i)
+			}
+		}
+		if len(pending) == 0 {
+			if mastery {
+				fmt.Printf("\n%s🏆 Every exercise mastered!%s\n", ColorSuccess, ColorReset)
+			}
+			break
+		}
+		if !mastery && round > 1 {
+			break
+		}
+
+		fmt.Println()
+		var input string
+		if mastery {
+			input = a.readLine(fmt.Sprintf("🔁 Round %d: %d left to master. Enter to start, h for hints, q to stop: ", round, len(pending)))
+		} else {
+			input = a.readLine(fmt.Sprintf("🔁 %d missed. Retry them? y = yes, h = with hints, Enter to skip: ", len(pending)))
+		}
+		input = strings.ToLower(input)
+		withHints := input == "h"
+		if (mastery && input == "q") || (!mastery && input != "y" && !withHints) {
+			break
+		}
+
+		rounds++
+		for n, i := range pending {
+			fmt.Printf("\n%sRetry %d/%d:%s\n", ColorText, n+1, len(pending), ColorReset)
+			match := a.askExercise(session, langInfo, nil, i, round, withHints)
+			if !match.Result.Accepted() {
+				continue
+			}
+			correct[i]++
+			if !solved[i] {
+				solved[i] = true
+				session.Fixed()
+			}
+		}
+	}
+	if rounds == 0 {
+		return
+	}
+
+	first, eventual := 0, 0
+	for i, result := range results {
+		if result.Accepted() {
+			first++
+		}
+		if solved[i] {
+			eventual++
+		}
+	}
+	fmt.Printf("\n%s📊 First try: %d/%d · In the end: %d/%d correct%s\n", ColorPrimary, first, len(results), eventual, len(results), ColorReset)
+	fmt.Println("──────────────────────────────────────────────────────────────────")
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/srs"
+)
+
+// reviewBatchSize caps how many cards one review round shows.
+const reviewBatchSize = 20
+
+func (a *App) dueCardsLabel() string {
+	if a.store == nil {
+		return ""
+	}
+	due, err := a.store.CountDue(a.currentConfig.Language, time.Now())
+	if err != nil || due == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d due today)", due)
+}
+
+func (a *App) reviewVocabulary() error {
+	if a.store == nil {
+		return fmt.Errorf("progress database unavailable")
+	}
+
+	langInfo := config.Languages[a.currentConfig.Language]
+	cards, err := a.store.DueCards(langInfo.Key, time.Now(), reviewBatchSize)
+	if err != nil {
+		return err
+	}
+
+	a.printHeader()
+	fmt.Println(ColorPrimary.Render("🔁 Vocabulary Review - " + langInfo.Display))
+	fmt.Println("══════════════════════════════════════════════════════════════════")
+
+	if len(cards) == 0 {
+		fmt.Println()
+		a.printSuccess("No cards due today. Come back tomorrow! 🎉")
+		return nil
+	}
+
+	reviewed, correct := 0, 0
+	for i, card := range cards {
+		fmt.Printf("\n%sCard %d/%d%s\n", ColorText, i+1, len(cards), ColorReset)
+
+		var typed string
+		var match answer.Match
+		if card.Direction == db.Recognition {
+			fmt.Printf("%s🔤 %s%s\n", ColorAccent, card.Word, ColorReset)
+			typed = a.readLine("Translation (q to stop): ")
+			checker := answer.Checker{Language: config.Languages["english"], Tolerance: a.currentConfig.AnswerTolerance}
+			match = checker.Check(typed, answer.Variants(card.Translation)...)
+			if match.Result == answer.Wrong {
+				match.Expected = card.Translation
+			}
+		} else {
+			fmt.Printf("%s🌍 %s%s\n", ColorAccent, card.Translation, ColorReset)
+			var given string
+			given, typed = a.readAnswer(langInfo.Name+" word (q to stop): ", langInfo)
+			match = a.checkAnswer(langInfo, given, card.Word)
+		}
+
+		if typed == "q" || typed == "quit" {
+			break
+		}
+
+		a.printMatch(match)
+		quality := srs.QualityWrong
+		switch match.Result {
+		case answer.Correct:
+			quality = a.askRecallQuality()
+			correct++
+		case answer.Almost:
+			quality = srs.QualityHard
+			correct++
+		}
+		if card.Example != "" {
+			fmt.Printf("   %s%s%s\n", ColorText, card.Example, ColorReset)
+		}
+
+		card.Card = card.Review(quality, time.Now())
+		if err := a.store.SaveReview(card, time.Now()); err != nil {
+			return err
+		}
+		reviewed++
+	}
+
+	fmt.Printf("\n%s📊 Reviewed %d cards, %d correct%s\n", ColorPrimary, reviewed, correct, ColorReset)
+	return nil
+}
+
+func (a *App) askRecallQuality() int {
+	input := a.readLine("How easy was it? [1] Hard [2] Good [3] Easy (Enter = Good): ")
+	switch strings.TrimSpace(input) {
+	case "1":
+		return srs.QualityHard
+	case "3":
+		return srs.QualityEasy
+	default:
+		return srs.QualityGood
+	}
+}
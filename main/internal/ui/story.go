@@ -1,92 +1,268 @@
 package ui
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/answer"
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/history"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/text"
+	"golang.org/x/term"
 )
 
-func (a *App) displayStory(story *ai.StoryResponse, language, level, topic string) error {
+func (a *App) displayStory(session *history.Session, record *storyRecord) error {
+	langInfo := config.Languages[session.Language]
+	a.printStory(session, "📖 Learning Session")
+
+	if !a.currentConfig.AutoTranslate {
+		a.revealTranslation(session.Story, langInfo)
+	}
+
+	// Run exercises
+	if err := a.runExercises(session, langInfo, record); err != nil {
+		return err
+	}
+	a.runClozeDrill(session, langInfo)
+
+	a.printSuccess("Lesson completed! Excellent work! 🎉")
+	return nil
+}
+
+func (a *App) printStory(session *history.Session, title string) {
+	story := session.Story
+
 	a.printHeader()
-	fmt.Println(ColorPrimary.Render("📖 Learning Session"))
+	fmt.Println(ColorPrimary.Render(title))
 	fmt.Println("══════════════════════════════════════════════════════════════════")
 	fmt.Println()
 
 	// Get language display name
-	langInfo := config.Languages[language]
+	langInfo := config.Languages[session.Language]
 
 	fmt.Printf("🌍 %sLanguage:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, langInfo.Display, ColorReset)
-	fmt.Printf("📊 %sLevel:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, level, ColorReset)
-	fmt.Printf("🎭 %sTopic:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, topic, ColorReset)
+	fmt.Printf("📊 %sLevel:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, session.Level, ColorReset)
+	fmt.Printf("🎭 %sTopic:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, session.Topic, ColorReset)
 	fmt.Println("──────────────────────────────────────────────────────────────────")
 	fmt.Println()
 
-	// Display story text
+	// Display story text with the vocabulary highlighted
+	var words []string
+	for _, vocab := range story.Vocabulary {
+		words = append(words, vocab.Word)
+		if vocab.Lemma != "" {
+			words = append(words, vocab.Lemma)
+		}
+	}
 	fmt.Printf("%s📖 Story:%s\n", ColorSuccess, ColorReset)
-	fmt.Printf("%s%s%s\n", ColorText, story.StoryText, ColorReset)
+	fmt.Printf("%s%s%s\n", ColorText, text.Highlight(story.StoryText, words, highlightWord), ColorReset)
+	if latin := a.transliterate(langInfo, story.StoryText); latin != "" {
+		fmt.Printf("%s🔡 %s%s\n", ColorInfo, latin, ColorReset)
+	}
 	fmt.Println()
 
-	// Display translation
-	fmt.Printf("%s🌍 Translation:%s\n", ColorInfo, ColorReset)
-	fmt.Printf("%s%s%s\n", ColorText, story.Translation, ColorReset)
+	// Display translation, or keep it hidden until the learner asks for it
+	if a.currentConfig.AutoTranslate {
+		fmt.Printf("%s🌍 Translation:%s\n", ColorInfo, ColorReset)
+		fmt.Printf("%s%s%s\n", ColorText, story.Translation, ColorReset)
+	} else {
+		fmt.Printf("%s🌍 Translation hidden%s\n", ColorInfo, ColorReset)
+	}
 	fmt.Println()
 
 	// Display vocabulary
 	fmt.Printf("%s📚 Vocabulary:%s\n", ColorWarning, ColorReset)
 	for _, vocab := range story.Vocabulary {
-		fmt.Printf("   %s• %s - %s%s\n", ColorText, vocab.Word, vocab.Translation, ColorReset)
+		fmt.Printf("   %s• %s%s%s - %s%s\n", ColorText, vocab.Word, a.latinSuffix(langInfo, vocab.Word), pronunciation(vocab.Pronunciation), vocab.Translation, ColorReset)
+		if note := grammarNote(vocab.Word, vocab.PartOfSpeech, vocab.Lemma, vocab.Features, langInfo.GrammarFeatures); note != "" {
+			fmt.Printf("     %s%s%s\n", ColorInfo, note, ColorReset)
+		}
+		if vocab.Example != "" {
+			fmt.Printf("     💬 %s\n", text.Highlight(vocab.Example, []string{vocab.Word}, highlightWord))
+			if latin := a.transliterate(langInfo, vocab.Example); latin != "" {
+				fmt.Printf("        %s🔡 %s%s\n", ColorInfo, latin, ColorReset)
+			}
+			if vocab.ExampleTranslation != "" {
+				fmt.Printf("        %s%s%s\n", ColorInfo, vocab.ExampleTranslation, ColorReset)
+			}
+		}
 	}
 	fmt.Println()
+}
 
-	// Run exercises
-	if err := a.runExercises(story); err != nil {
-		return err
-	}
+func highlightWord(word string) string {
+	return ColorAccent.Bold(true).Render(word)
+}
 
-	a.printSuccess("Lesson completed! Excellent work! 🎉")
-	return nil
+// revealTranslation lets the learner uncover a hidden translation either all
+// at once or one sentence at a time, each shown under its original sentence.
+func (a *App) revealTranslation(story *ai.StoryResponse, langInfo config.Language) {
+	originals := text.Sentences(story.StoryText, langInfo.SentenceDelimiters)
+	translations := text.Sentences(story.Translation, config.Languages["english"].SentenceDelimiters)
+	revealed := 0
+
+	for revealed < len(translations) {
+		input := a.readLine("Reveal translation: [s]entence, [a]ll, Enter to continue: ")
+
+		switch strings.ToLower(input) {
+		case "s", "sentence":
+			if revealed < len(originals) {
+				fmt.Printf("%s%s%s\n", ColorAccent, originals[revealed], ColorReset)
+			}
+			fmt.Printf("   %s%s%s\n", ColorText, translations[revealed], ColorReset)
+			revealed++
+		case "a", "all":
+			fmt.Printf("%s🌍 Translation:%s\n", ColorInfo, ColorReset)
+			fmt.Printf("%s%s%s\n", ColorText, story.Translation, ColorReset)
+			fmt.Println()
+			return
+		case "":
+			fmt.Println()
+			return
+		}
+	}
+	fmt.Println()
 }
 
-func (a *App) runExercises(story *ai.StoryResponse) error {
+func (a *App) runExercises(session *history.Session, langInfo config.Language, record *storyRecord) error {
+	story := session.Story
 	if len(story.Exercises) == 0 {
 		return nil
 	}
 
 	fmt.Println(ColorPrimary.Render("💪 Practice Exercises"))
 	fmt.Println("──────────────────────────────────────────────────────────────────")
+	fmt.Printf("%sType %s for a hint; each hint costs a quarter point.%s\n", ColorInfo, hintRequest, ColorReset)
 
-	correctAnswers := 0
-	reader := bufio.NewReader(os.Stdin)
-
-	for i, exercise := range story.Exercises {
-		fmt.Printf("\n%sExercise %d/%d:%s\n", ColorText, i+1, len(story.Exercises), ColorReset)
-		fmt.Printf("%sQ: %s%s\n", ColorText, exercise.Question, ColorReset)
+	a.timer = newQuizTimer(a.currentConfig, time.Now())
+	if a.timer != nil {
+		fmt.Printf("%s⏱️ Timed quiz: %s. Unanswered exercises are skipped.%s\n", ColorWarning, a.timer.describe(), ColorReset)
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			a.printWarning("Input is not a terminal, so time limits are only checked after each answer")
+		}
+	}
 
-		if exercise.Type == "multiple_choice" && len(exercise.Options) > 0 {
-			fmt.Printf("%sOptions:%s\n", ColorInfo, ColorReset)
-			for _, option := range exercise.Options {
-				fmt.Printf("   %s%s\n", ColorText, option)
+	results := make([]answer.Result, len(story.Exercises))
+	for i := range story.Exercises {
+		if a.timer.over() {
+			fmt.Printf("\n%s⏰ Time is up! %d exercises skipped.%s\n", ColorWarning, len(story.Exercises)-i, ColorReset)
+			for ; i < len(story.Exercises); i++ {
+				a.skipExercise(session, record, i)
 			}
+			break
 		}
+		fmt.Printf("\n%sExercise %d/%d:%s%s\n", ColorText, i+1, len(story.Exercises), ColorReset, a.timer.quizLeft())
+		results[i] = a.askExercise(session, langInfo, record, i, 0, false).Result
+	}
+	// Retry rounds are untimed
+	a.timer = nil
+
+	a.printScore(session)
+	fmt.Println("──────────────────────────────────────────────────────────────────")
+
+	a.retryExercises(session, langInfo, results)
+	return nil
+}
+
+// printScore prints the first-try score, with the cost of any hints.
+func (a *App) printScore(session *history.Session) {
+	if session.HintPenalty > 0 {
+		fmt.Printf("\n%s📊 Score: %g/%d (%d correct, -%g for hints)%s\n", ColorPrimary, session.Points(), session.Total, session.Score, session.HintPenalty, ColorReset)
+		return
+	}
+	fmt.Printf("\n%s📊 Score: %d/%d correct%s\n", ColorPrimary, session.Score, session.Total, ColorReset)
+}
 
-		fmt.Println()
-		fmt.Print(ColorInfo.Render("Your answer: "))
-		userAnswer, _ := reader.ReadString('\n')
-		userAnswer = strings.TrimSpace(userAnswer)
+// askExercise runs exercise i of the story and records the answer. retry is
+// the retry round, 0 for the first try; only first tries are saved to the
+// progress database.
+func (a *App) askExercise(session *history.Session, langInfo config.Language, record *storyRecord, i, retry int, withHint bool) answer.Match {
+	story := session.Story
+	exercise := story.Exercises[i]
 
-		if userAnswer == exercise.Answer {
-			fmt.Printf("%s✅ Correct!%s\n", ColorSuccess, ColorReset)
-			correctAnswers++
-		} else {
-			fmt.Printf("%s❌ The answer is: %s%s\n", ColorError, exercise.Answer, ColorReset)
+	// Later rounds shuffle options differently
+	ex := &practice{Exercise: exercise, SessionID: session.ID, Index: i + retry*len(story.Exercises), Language: langInfo}
+	kind := lookupExerciseType(exercise)
+	kind.Render(a, ex)
+
+	a.hint = newHinter(story, ex)
+	if withHint {
+		a.showHint()
+	}
+	started := a.timer.start()
+	response := kind.Collect(a, ex)
+	elapsed := time.Since(started)
+	timedOut := a.timer.expired()
+	a.timer.stop()
+	// Grading and reviewing the answer don't count against the quiz
+	a.timer.pause()
+	defer a.timer.resume()
+	hints := a.hint.used
+	a.hint = nil
+
+	if timedOut {
+		response.Given, response.Typed, response.Choice = "", "", ""
+	}
+	match := kind.Grade(a, ex, response)
+	if timedOut {
+		match.Result = answer.Wrong
+	}
+
+	entry := history.Answer{
+		Type:       exercise.Type,
+		Question:   exercise.Question,
+		Expected:   exercise.Answer,
+		Given:      response.Given,
+		Choice:     response.Choice,
+		Correct:    match.Result.Accepted(),
+		Almost:     match.Result == answer.Almost,
+		Score:      match.Score,
+		Feedback:   match.Explanation,
+		Hints:      hints,
+		ResponseMS: elapsed.Milliseconds(),
+		TimedOut:   timedOut,
+		Retry:      retry,
+		AnsweredAt: time.Now(),
+	}
+	session.Record(entry)
+	if retry == 0 {
+		if err := record.answer(i, entry); err != nil {
+			a.printWarning("Answer not saved: " + err.Error())
 		}
 	}
 
-	fmt.Printf("\n%s📊 Score: %d/%d correct%s\n", ColorPrimary, correctAnswers, len(story.Exercises), ColorReset)
-	fmt.Println("──────────────────────────────────────────────────────────────────")
-	return nil
+	switch {
+	case timedOut:
+		fmt.Printf("%s⏰ Time's up! %s%s\n", ColorWarning, kind.Explain(a, ex, match), ColorReset)
+	case match.Result == answer.Correct:
+		fmt.Printf("%s✅ Correct!%s\n", ColorSuccess, ColorReset)
+	case match.Result == answer.Almost:
+		fmt.Printf("%s🟡 Almost correct! %s%s\n", ColorWarning, kind.Explain(a, ex, match), ColorReset)
+	default:
+		fmt.Printf("%s❌ %s%s\n", ColorError, kind.Explain(a, ex, match), ColorReset)
+		if typedAnswer(kind) {
+			a.reviewMistake(story, ex, response.Given, match)
+		}
+	}
+	return match
+}
+
+// skipExercise records exercise i as missed when the quiz ran out of time
+// before it.
+func (a *App) skipExercise(session *history.Session, record *storyRecord, i int) {
+	exercise := session.Story.Exercises[i]
+	entry := history.Answer{
+		Type:       exercise.Type,
+		Question:   exercise.Question,
+		Expected:   exercise.Answer,
+		TimedOut:   true,
+		AnsweredAt: time.Now(),
+	}
+	session.Record(entry)
+	if err := record.answer(i, entry); err != nil {
+		a.printWarning("Answer not saved: " + err.Error())
+	}
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
 )
@@ -16,9 +17,24 @@ func (a *App) printHeader() {
 	fmt.Println(ColorPrimary.Render("║                 " + ColorText.Render("Polyglot AI Storyteller") + ColorPrimary.Render(" v") + config.Version + "           ║"))
 	fmt.Println(ColorPrimary.Render("║               Cloud-Powered Language Learning                   ║"))
 	fmt.Println(ColorPrimary.Render("╚══════════════════════════════════════════════════════════════════╝"))
+	if a.currentConfig != nil {
+		a.printGoalStatus()
+	}
 	fmt.Println()
 }
 
+func (a *App) printGoalStatus() {
+	now := time.Now()
+	today := a.progress.Today(now, a.currentConfig.DailyGoal)
+
+	goal := fmt.Sprintf("🎯 Today: %d/%d", today.Completed, today.Goal)
+	if today.GoalMet() {
+		goal += " ✅"
+	}
+	streak := fmt.Sprintf("🔥 Streak: %d days (best %d)", a.progress.Streak(now), a.progress.BestStreak())
+	fmt.Println(ColorText.Render("   " + goal + "   " + streak))
+}
+
 func (a *App) printStatus(emoji, message string) {
 	fmt.Println(ColorInfo.Render(emoji + " " + message))
 }
@@ -56,6 +72,12 @@ func (a *App) getUserChoice(prompt string, min, max int) (int, bool) {
 	}
 }
 
+func (a *App) readLine(prompt string) string {
+	fmt.Print(ColorInfo.Render(prompt))
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(input)
+}
+
 func (a *App) waitForInput() {
 	fmt.Println()
 	fmt.Print(ColorInfo.Render("Press any key to continue..."))
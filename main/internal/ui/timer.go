@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+)
+
+// errTimeout is returned by the line editor when the answer's time is up.
+var errTimeout = errors.New("time is up")
+
+// quizTimer holds the time limits of a timed quiz. A nil timer never
+// expires, so untimed exercises can use it freely.
+type quizTimer struct {
+	question time.Duration // per question, 0 for no limit
+	end      time.Time     // end of the whole quiz, zero for no limit
+	deadline time.Time     // of the question being answered
+	paused   time.Time     // when the clock was paused, zero while running
+}
+
+// newQuizTimer returns a timer for the configured limits, or nil when timed
+// mode is off or has no limits.
+func newQuizTimer(cfg *config.Config, now time.Time) *quizTimer {
+	if !cfg.TimedMode || (cfg.QuestionTimeLimit == 0 && cfg.QuizTimeLimit == 0) {
+		return nil
+	}
+	t := &quizTimer{question: time.Duration(cfg.QuestionTimeLimit) * time.Second}
+	if cfg.QuizTimeLimit > 0 {
+		t.end = now.Add(time.Duration(cfg.QuizTimeLimit) * time.Second)
+	}
+	return t
+}
+
+// start sets the deadline of the next question and returns the time the
+// answer is measured from.
+func (t *quizTimer) start() time.Time {
+	now := time.Now()
+	if t == nil {
+		return now
+	}
+	t.deadline = t.end
+	if t.question > 0 && (t.deadline.IsZero() || now.Add(t.question).Before(t.deadline)) {
+		t.deadline = now.Add(t.question)
+	}
+	return now
+}
+
+// stop clears the deadline once the question is answered.
+func (t *quizTimer) stop() {
+	if t != nil {
+		t.deadline = time.Time{}
+	}
+}
+
+// pause stops the quiz clock while the app, not the learner, is busy, such
+// as waiting for AI grading or an explanation.
+func (t *quizTimer) pause() {
+	if t != nil && t.paused.IsZero() {
+		t.paused = time.Now()
+	}
+}
+
+// resume restarts the quiz clock, moving the end of the quiz by the time
+// it was paused.
+func (t *quizTimer) resume() {
+	if t == nil || t.paused.IsZero() {
+		return
+	}
+	if !t.end.IsZero() {
+		t.end = t.end.Add(time.Since(t.paused))
+	}
+	t.paused = time.Time{}
+}
+
+// expired reports whether the question being answered ran out of time.
+func (t *quizTimer) expired() bool {
+	return t != nil && !t.deadline.IsZero() && !time.Now().Before(t.deadline)
+}
+
+// over reports whether the time for the whole quiz is up.
+func (t *quizTimer) over() bool {
+	return t != nil && !t.end.IsZero() && !time.Now().Before(t.end)
+}
+
+// quizLeft renders the time left for the whole quiz, or "" without a
+// total limit.
+func (t *quizTimer) quizLeft() string {
+	if t == nil || t.end.IsZero() {
+		return ""
+	}
+	return "   " + countdown(t.end) + ColorInfo.Render(" left")
+}
+
+// describe explains the limits, like "20s per question, 5m0s in total".
+func (t *quizTimer) describe() string {
+	limits := "no time limit per question"
+	if t.question > 0 {
+		limits = fmt.Sprintf("%s per question", t.question)
+	}
+	if !t.end.IsZero() {
+		limits += fmt.Sprintf(", %s in total", time.Until(t.end).Round(time.Second))
+	}
+	return limits
+}
+
+// countdown renders the time left to answer.
+func countdown(deadline time.Time) string {
+	left := max(time.Until(deadline).Round(time.Second), 0)
+	style := ColorInfo
+	if left <= 5*time.Second {
+		style = ColorError
+	}
+	return style.Render(fmt.Sprintf("⏱️ %s", left))
+}
@@ -1,8 +1,14 @@
 package ui
 
 import (
+	"path/filepath"
+
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ai"
 	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/history"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/plugin"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/progress"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -22,11 +28,20 @@ type App struct {
 	configManager *config.Manager
 	aiClient      *ai.Client
 	currentConfig *config.Config
+	progress      *progress.Tracker
+	history       history.Store
+	store         *db.DB
+	userID        int64
+	plugins       []*plugin.Plugin
+	hint          *hinter    // hints for the exercise being answered
+	timer         *quizTimer // time limits of a timed quiz
 }
 
 func NewApp(cfgManager *config.Manager) *App {
 	return &App{
 		configManager: cfgManager,
 		aiClient:      ai.NewClient(),
+		progress:      progress.NewTracker(cfgManager.DataDir()),
+		history:       history.NewFileStore(filepath.Join(cfgManager.DataDir(), "sessions")),
 	}
 }
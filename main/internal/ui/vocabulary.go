@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
+	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/db"
+)
+
+// notebookPageSize caps how many words the notebook lists at once.
+const notebookPageSize = 30
+
+func (a *App) showVocabularyNotebook() error {
+	if a.store == nil {
+		return fmt.Errorf("progress database unavailable")
+	}
+
+	langInfo := config.Languages[a.currentConfig.Language]
+	filter := db.VocabFilter{Language: langInfo.Key}
+	storyTopic := ""
+
+	for {
+		entries, err := a.store.ListVocabulary(filter)
+		if err != nil {
+			return err
+		}
+
+		a.printHeader()
+		fmt.Println(ColorPrimary.Render("📚 Vocabulary Notebook - " + langInfo.Display))
+		fmt.Println("══════════════════════════════════════════════════════════════════")
+		if description := describeFilter(filter, storyTopic); description != "" {
+			fmt.Printf("%s🔎 %s%s\n", ColorInfo, description, ColorReset)
+		}
+		fmt.Println()
+
+		if len(entries) == 0 {
+			a.printWarning("No words match")
+		}
+		shown := entries
+		if len(shown) > notebookPageSize {
+			shown = shown[:notebookPageSize]
+		}
+		for i, entry := range shown {
+			fmt.Printf("   %s%2d.%s %s\n", ColorText, i+1, ColorReset, formatVocabEntry(entry))
+		}
+		if len(entries) > len(shown) {
+			fmt.Printf("   %s… %d more, narrow the filter to see them%s\n", ColorText, len(entries)-len(shown), ColorReset)
+		}
+		fmt.Println()
+
+		fmt.Println(ColorText.Render("[number] open  [s]earch  [l]evel  [t]ag  [o] story  [k] known words  [a]dd  [c]lear  [q] back"))
+		input := a.readLine("Choice: ")
+
+		if choice, err := strconv.Atoi(input); err == nil {
+			if choice >= 1 && choice <= len(shown) {
+				if err := a.editVocabEntry(shown[choice-1].ID); err != nil {
+					a.printError(err.Error())
+					a.waitForInput()
+				}
+			}
+			continue
+		}
+
+		switch strings.ToLower(input) {
+		case "s":
+			filter.Search = a.readLine("Search word or translation: ")
+		case "l":
+			level := strings.ToUpper(a.readLine("Level (" + strings.Join(config.LevelCodes, ", ") + ", empty for all): "))
+			if _, ok := config.Levels[level]; ok || level == "" {
+				filter.Level = level
+			}
+		case "t":
+			tags, err := a.store.Tags()
+			if err != nil {
+				return err
+			}
+			if len(tags) > 0 {
+				fmt.Println(ColorText.Render("Tags: " + strings.Join(tags, ", ")))
+			}
+			filter.Tag = a.readLine("Tag (empty for all): ")
+		case "o":
+			storyID, topic, err := a.chooseStory(langInfo.Key)
+			if err != nil {
+				return err
+			}
+			filter.StoryID, storyTopic = storyID, topic
+		case "k":
+			filter.IncludeKnown = !filter.IncludeKnown
+		case "a":
+			if err := a.addVocabEntry(langInfo); err != nil {
+				a.printError(err.Error())
+				a.waitForInput()
+			}
+		case "c":
+			filter = db.VocabFilter{Language: langInfo.Key}
+			storyTopic = ""
+		case "q", "quit", "":
+			return nil
+		}
+	}
+}
+
+func (a *App) chooseStory(language string) (int64, string, error) {
+	stories, err := a.store.Stories(language)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(stories) == 0 {
+		return 0, "", nil
+	}
+	if len(stories) > historyPageSize {
+		stories = stories[:historyPageSize]
+	}
+
+	fmt.Println()
+	for i, story := range stories {
+		created := "????-??-??"
+		if !story.CreatedAt.IsZero() {
+			created = story.CreatedAt.Local().Format("2006-01-02")
+		}
+		fmt.Printf("   %s%2d.%s %s  %s  %s\n", ColorText, i+1, ColorReset, created, story.Level, story.Topic)
+	}
+	choice, quit := a.getUserChoice(fmt.Sprintf("Story (1-%d, q for all): ", len(stories)), 1, len(stories))
+	if quit {
+		return 0, "", nil
+	}
+	return stories[choice-1].ID, stories[choice-1].Topic, nil
+}
+
+func (a *App) editVocabEntry(id int64) error {
+	for {
+		entry, err := a.store.GetVocabulary(id)
+		if err != nil {
+			return err
+		}
+
+		a.printHeader()
+		fmt.Println(ColorPrimary.Render("📝 " + entry.Word))
+		fmt.Println("──────────────────────────────────────────────────────────────────")
+		fmt.Printf("🌍 %sTranslation:%s %s%s%s\n", ColorText, ColorReset, ColorAccent, entry.Translation, ColorReset)
+		if latin := a.transliterate(config.Languages[entry.Language], entry.Word); latin != "" {
+			fmt.Printf("🔡 %sLatin:%s %s\n", ColorText, ColorReset, latin)
+		}
+		if entry.Pronunciation != "" {
+			fmt.Printf("🔊 %sPronunciation:%s%s\n", ColorText, ColorReset, pronunciation(entry.Pronunciation))
+		}
+		if note := grammarNote(entry.Word, entry.PartOfSpeech, entry.Lemma, entry.Features, config.Languages[entry.Language].GrammarFeatures); note != "" {
+			fmt.Printf("🔤 %sGrammar:%s %s\n", ColorText, ColorReset, note)
+		}
+		if entry.Example != "" {
+			fmt.Printf("💬 %sExample:%s %s\n", ColorText, ColorReset, entry.Example)
+			if entry.ExampleTranslation != "" {
+				fmt.Printf("   %s%s%s\n", ColorText, entry.ExampleTranslation, ColorReset)
+			}
+		}
+		fmt.Printf("📊 %sLevel:%s %s\n", ColorText, ColorReset, entry.Level)
+		if entry.StoryTopic != "" {
+			fmt.Printf("📖 %sFrom story:%s %s\n", ColorText, ColorReset, entry.StoryTopic)
+		}
+		fmt.Printf("👀 %sSeen:%s %d times\n", ColorText, ColorReset, entry.TimesEncountered)
+		fmt.Printf("🏷️ %sTags:%s %s\n", ColorText, ColorReset, strings.Join(entry.Tags, ", "))
+		fmt.Printf("✅ %sKnown:%s %v\n", ColorText, ColorReset, entry.Known)
+		fmt.Println()
+
+		knownLabel := "Mark as known"
+		if entry.Known {
+			knownLabel = "Mark as not known"
+		}
+		fmt.Printf("   %s1. ✏️ Fix translation%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s2. 💬 Edit example%s\n", ColorInfo, ColorReset)
+		fmt.Printf("   %s3. ✅ %s%s\n", ColorSuccess, knownLabel, ColorReset)
+		fmt.Printf("   %s4. 🏷️ Add tag%s\n", ColorWarning, ColorReset)
+		fmt.Printf("   %s5. 🏷️ Remove tag%s\n", ColorWarning, ColorReset)
+		fmt.Printf("   %s6. ↩️ Back%s\n", ColorText, ColorReset)
+		fmt.Println()
+
+		choice, quit := a.getUserChoice("Choose option (1-6): ", 1, 6)
+		if quit || choice == 6 {
+			return nil
+		}
+
+		switch choice {
+		case 1:
+			if translation := a.readLine("New translation: "); translation != "" {
+				err = a.store.UpdateVocabulary(id, translation, "")
+			}
+		case 2:
+			if example := a.readLine("New example: "); example != "" {
+				err = a.store.UpdateVocabulary(id, "", example)
+			}
+		case 3:
+			err = a.store.SetKnown(id, !entry.Known)
+		case 4:
+			err = a.store.AddTag(id, a.readLine("Tag: "))
+		case 5:
+			err = a.store.RemoveTag(id, a.readLine("Tag: "))
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (a *App) addVocabEntry(langInfo config.Language) error {
+	word := a.readLine(langInfo.Name + " word: ")
+	if word == "" {
+		return nil
+	}
+	translation := a.readLine("Translation: ")
+	example := a.readLine("Example sentence (optional): ")
+	tags := strings.Split(a.readLine("Tags, comma separated (optional): "), ",")
+
+	_, err := a.store.AddVocabulary(db.VocabEntry{
+		Language:    langInfo.Key,
+		Word:        word,
+		Translation: translation,
+		Example:     example,
+		Level:       a.currentConfig.Level,
+		Tags:        tags,
+	})
+	return err
+}
+
+func formatVocabEntry(entry db.VocabEntry) string {
+	line := fmt.Sprintf("%s - %s %s(%s)%s", entry.Word, entry.Translation, ColorText, entry.Level, ColorReset)
+	for _, tag := range entry.Tags {
+		line += " " + ColorAccent.Render("#"+tag)
+	}
+	if entry.Known {
+		line += " " + ColorSuccess.Render("✓ known")
+	}
+	return line
+}
+
+func describeFilter(filter db.VocabFilter, storyTopic string) string {
+	var parts []string
+	if filter.Search != "" {
+		parts = append(parts, fmt.Sprintf("search %q", filter.Search))
+	}
+	if filter.Level != "" {
+		parts = append(parts, "level "+filter.Level)
+	}
+	if filter.Tag != "" {
+		parts = append(parts, "tag #"+filter.Tag)
+	}
+	if filter.StoryID != 0 {
+		parts = append(parts, "story "+storyTopic)
+	}
+	if filter.IncludeKnown {
+		parts = append(parts, "including known words")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -1,24 +1,7 @@
 package main
 
-import (
-	"fmt"
-	"os"
-
-	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/config"
-	"github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/internal/ui"
-)
+import "github.com/Mohammad-Ali-Rauf/polyglot-storyweaver/cmd"
 
 func main() {
-	// Initialize configuration
-	cfgManager, err := config.NewManager()
-	if err != nil {
-		fmt.Printf("Error initializing config: %v\n", err)
-		os.Exit(1)
-	}
-
-	app := ui.NewApp(cfgManager)
-	if err := app.Run(); err != nil {
-		fmt.Printf("Error running application: %v\n", err)
-		os.Exit(1)
-	}
+	cmd.Execute()
 }